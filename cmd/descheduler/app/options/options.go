@@ -22,6 +22,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
 
+	componentbaseoptions "k8s.io/component-base/config/options"
 	"k8s.io/component-base/logs"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig/v1alpha1"
@@ -70,11 +71,25 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&rs.DeschedulingInterval, "descheduling-interval", rs.DeschedulingInterval, "Time interval between two consecutive descheduler executions. Setting this value instructs the descheduler to run in a continuous loop at the interval specified.")
 	fs.StringVar(&rs.KubeconfigFile, "kubeconfig", rs.KubeconfigFile, "File with  kube configuration.")
 	fs.StringVar(&rs.PolicyConfigFile, "policy-config-file", rs.PolicyConfigFile, "File with descheduler policy configuration.")
+	fs.StringVar(&rs.PolicyName, "policy-name", rs.PolicyName, "Name of the cluster-scoped DeschedulerPolicy custom resource to use instead of --policy-config-file.")
 	fs.BoolVar(&rs.DryRun, "dry-run", rs.DryRun, "execute descheduler in dry run mode.")
+	fs.StringVar(&rs.DryRunReportFormat, "dry-run-report-format", rs.DryRunReportFormat, "Format of the report of the evictions planned in each descheduling cycle in dry run mode: json, yaml or table. No report is written if empty.")
+	fs.StringVar(&rs.DryRunReportFile, "dry-run-report-file", rs.DryRunReportFile, "File the dry run report is written to, replaced in every descheduling cycle. The report is written to stdout if empty.")
 	// node-selector query causes descheduler to run only on nodes that matches the node labels in the query
 	fs.StringVar(&rs.NodeSelector, "node-selector", rs.NodeSelector, "DEPRECATED: selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	// max-no-pods-to-evict limits the maximum number of pods to be evicted per node by descheduler.
 	fs.IntVar(&rs.MaxNoOfPodsToEvictPerNode, "max-pods-to-evict-per-node", rs.MaxNoOfPodsToEvictPerNode, "DEPRECATED: limits the maximum number of pods to be evicted per node by descheduler")
 	// evict-local-storage-pods allows eviction of pods that are using local storage. This is false by default.
 	fs.BoolVar(&rs.EvictLocalStoragePods, "evict-local-storage-pods", rs.EvictLocalStoragePods, "DEPRECATED: enables evicting pods using local storage by descheduler")
+
+	fs.StringVar(&rs.MetricsBindAddress, "metrics-bind-address", rs.MetricsBindAddress, "The address the /metrics endpoint is served on, e.g. :10258. The endpoint is disabled if empty.")
+	fs.DurationVar(&rs.ShutdownTimeout, "shutdown-timeout", rs.ShutdownTimeout, "How long to wait for running strategies and evictions to finish after SIGTERM or SIGINT before exiting. It should be shorter than the terminationGracePeriodSeconds of the descheduler pod.")
+
+	fs.StringVar(&rs.EvictionHistoryNamespace, "eviction-history-namespace", rs.EvictionHistoryNamespace, "Namespace of the ConfigMap the evictions are recorded in when the policy sets an evictionCooldown.")
+	fs.StringVar(&rs.EvictionHistoryName, "eviction-history-name", rs.EvictionHistoryName, "Name of the ConfigMap the evictions are recorded in when the policy sets an evictionCooldown.")
+
+	fs.StringVar(&rs.NotificationAuditFile, "notification-audit-file", rs.NotificationAuditFile, "File the evictions of each descheduling cycle are appended to as JSON lines. No audit file is written if empty.")
+	fs.StringVar(&rs.NotificationWebhookURL, "notification-webhook-url", rs.NotificationWebhookURL, "HTTP(S) endpoint the evictions of each descheduling cycle are posted to as a batch. No notifications are sent if empty.")
+
+	componentbaseoptions.BindLeaderElectionFlags(&rs.LeaderElection, fs)
 }
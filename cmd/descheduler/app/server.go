@@ -18,8 +18,12 @@ limitations under the License.
 package app
 
 import (
+	"context"
 	"flag"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/descheduler"
@@ -49,7 +53,7 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 			if err := s.Validate(); err != nil {
 				klog.ErrorS(err, "failed to validate server configuration")
 			}
-			err := Run(s)
+			err := Run(setupSignalContext(), s)
 			if err != nil {
 				klog.ErrorS(err, "descheduler server")
 			}
@@ -63,6 +67,22 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 	return cmd
 }
 
-func Run(rs *options.DeschedulerServer) error {
-	return descheduler.Run(rs)
+func Run(ctx context.Context, rs *options.DeschedulerServer) error {
+	return descheduler.Run(ctx, rs)
+}
+
+// setupSignalContext returns a context which is cancelled on SIGTERM or SIGINT, so
+// the descheduler can finish the running evictions. A second signal exits immediately.
+func setupSignalContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		sig := <-signals
+		klog.InfoS("Received signal, shutting down", "signal", sig)
+		cancel()
+		<-signals
+		os.Exit(1)
+	}()
+	return ctx
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// NewSimulateCommand creates a command running a policy in dry run mode against a
+// snapshot of a cluster, without an API server, and printing the planned evictions.
+func NewSimulateCommand(out io.Writer) *cobra.Command {
+	var snapshotDir, policyConfigFile, output string
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate a descheduler policy against a cluster snapshot",
+		Long: `Loads the Nodes, Pods, PriorityClasses, Namespaces and PodDisruptionBudgets of a cluster from
+the YAML and JSON files in the snapshot directory, runs the policy once in dry run mode against them
+and prints the evictions the descheduler would make.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return simulate(out, snapshotDir, policyConfigFile, output)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.SetOut(out)
+	flags := cmd.Flags()
+	flags.StringVar(&snapshotDir, "snapshot", "", "Directory with the YAML or JSON dumps of the cluster objects.")
+	flags.StringVar(&policyConfigFile, "policy-config-file", "", "File with descheduler policy configuration.")
+	flags.StringVarP(&output, "output", "o", evictions.ReportFormatTable, fmt.Sprintf("Format the planned evictions are printed in, one of %v.", evictions.ReportFormats))
+	cmd.MarkFlagRequired("snapshot")
+	cmd.MarkFlagRequired("policy-config-file")
+	return cmd
+}
+
+func simulate(out io.Writer, snapshotDir, policyConfigFile, output string) error {
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		return err
+	}
+	policy, err := descheduler.LoadPolicyConfig(policyConfigFile)
+	if err != nil {
+		return err
+	}
+	objects, err := descheduler.LoadSnapshot(snapshotDir)
+	if err != nil {
+		return fmt.Errorf("unable to load snapshot: %v", err)
+	}
+	return descheduler.Simulate(context.Background(), rs, policy, objects, output, out)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+)
+
+// NewValidateCommand creates a command checking a policy config file without running
+// the descheduler. It exits non-zero when the policy is invalid, so it can gate
+// policy changes in CI.
+func NewValidateCommand(out io.Writer) *cobra.Command {
+	var policyConfigFile string
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a descheduler policy",
+		Long:  `Checks the policy config file and prints every problem with the strategy and the field it was found at.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validatePolicyConfig(out, policyConfigFile)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.SetOut(out)
+	cmd.Flags().StringVar(&policyConfigFile, "policy-config-file", "", "File with descheduler policy configuration.")
+	cmd.MarkFlagRequired("policy-config-file")
+	return cmd
+}
+
+func validatePolicyConfig(out io.Writer, policyConfigFile string) error {
+	_, err := descheduler.LoadPolicyConfig(policyConfigFile)
+	if err == nil {
+		fmt.Fprintf(out, "Policy config file %q is valid\n", policyConfigFile)
+		return nil
+	}
+
+	var agg utilerrors.Aggregate
+	if !errors.As(err, &agg) {
+		return err
+	}
+	for _, err := range agg.Errors() {
+		fmt.Fprintln(out, err)
+	}
+	return fmt.Errorf("policy config file %q is invalid: found %d problem(s)", policyConfigFile, len(agg.Errors()))
+}
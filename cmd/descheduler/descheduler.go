@@ -27,6 +27,8 @@ func main() {
 	out := os.Stdout
 	cmd := app.NewDeschedulerCommand(out)
 	cmd.AddCommand(app.NewVersionCommand())
+	cmd.AddCommand(app.NewValidateCommand(out))
+	cmd.AddCommand(app.NewSimulateCommand(out))
 
 	logs.InitLogs()
 	defer logs.FlushLogs()
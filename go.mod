@@ -5,6 +5,7 @@ go 1.15
 require (
 	github.com/client9/misspell v0.3.4
 	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/pflag v1.0.5
 	k8s.io/api v0.20.0
@@ -15,4 +16,5 @@ require (
 	k8s.io/component-base v0.20.0
 	k8s.io/component-helpers v0.20.0
 	k8s.io/klog/v2 v2.4.0
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -25,6 +25,8 @@ import (
 
 type DeschedulerPolicy struct {
 	metav1.TypeMeta
+	// Standard object's metadata, set when the policy is served as a DeschedulerPolicy custom resource.
+	metav1.ObjectMeta
 
 	// Strategies
 	Strategies StrategyList
@@ -40,6 +42,55 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
 	MaxNoOfPodsToEvictPerNode *int
+
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
+	MaxNoOfPodsToEvictPerNamespace *int
+
+	// MaxNoOfPodsToEvictPerOwner restricts maximum of pods to be evicted per owning workload.
+	MaxNoOfPodsToEvictPerOwner *int
+
+	// MaxNoOfPodsToEvictPerZone restricts maximum of pods to be evicted per topology zone of the nodes.
+	MaxNoOfPodsToEvictPerZone *int
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted in a descheduling cycle.
+	MaxNoOfPodsToEvictTotal *int
+
+	// EvictionsPerSecond limits the rate of evictions, they are spread over time instead of being made all at once.
+	EvictionsPerSecond *float32
+
+	// EvictionBurst is the number of evictions which can be made at once when EvictionsPerSecond is set, defaults to 1.
+	EvictionBurst *int
+
+	// EvictionCooldown skips the pods of workloads which had a pod evicted within the cooldown,
+	// whichever strategy evicted it. The evictions are recorded in a ConfigMap, so the cooldown
+	// also applies across runs of the descheduler.
+	EvictionCooldown *metav1.Duration
+
+	// ReplacementTimeout paces the evictions of the pods of a ReplicaSet or StatefulSet. The next
+	// eviction of the same owner waits until the replacement of the previously evicted pod is ready,
+	// while the pods of other owners are evicted in the meantime. The deferred evictions of a cycle
+	// are given up once the timeout passed since the first one was deferred. It has no effect
+	// together with EvictionCooldown, which allows only one eviction per owner in a cycle.
+	ReplacementTimeout *metav1.Duration
+
+	// DeleteOptions are the options of the deletion of evicted pods. Strategies can override them.
+	DeleteOptions *DeleteOptions
+
+	// ApprovalWebhook is asked to approve every eviction before it is made.
+	ApprovalWebhook *ApprovalWebhook
+
+	// Status is reported by the descheduler when the policy is served as a DeschedulerPolicy custom resource.
+	Status DeschedulerPolicyStatus
+}
+
+// DeschedulerPolicyStatus tells whether the descheduler accepted the policy.
+type DeschedulerPolicyStatus struct {
+	// ObservedGeneration is the generation of the policy the conditions were reported for.
+	ObservedGeneration int64
+
+	// Conditions of the policy. The Valid condition is false when the policy failed
+	// validation and its message lists the errors.
+	Conditions []metav1.Condition
 }
 
 type StrategyName string
@@ -49,11 +100,59 @@ type DeschedulerStrategy struct {
 	// Enabled or disabled
 	Enabled bool
 
-	// Weight
+	// Weight orders the strategies within a descheduling cycle. Strategies with
+	// a higher weight run first, strategies with the same weight run in order of their names.
 	Weight int
 
 	// Strategy parameters
 	Params *StrategyParameters
+
+	// Interval runs the strategy on its own loop with the given period instead of
+	// the DeschedulingInterval loop. Only one of Interval and Schedule can be set.
+	Interval *metav1.Duration
+
+	// Schedule runs the strategy on its own loop at the times given by a cron
+	// expression, e.g. "0 2 * * *". Only one of Interval and Schedule can be set.
+	Schedule string
+
+	// Parallelism is the number of nodes processed at the same time by strategies
+	// which handle each node on its own. The nodes are processed one by one if it is not set.
+	Parallelism int
+
+	// DeleteOptions override the DeleteOptions of the policy for the pods evicted by the strategy.
+	DeleteOptions *DeleteOptions
+
+	// DisruptionMode is how the pods selected by the strategy are disrupted, one of Evict,
+	// RolloutRestart and Delete. The pods are evicted if it is not set.
+	DisruptionMode string
+}
+
+// DeleteOptions are the options the API server deletes an evicted pod with.
+type DeleteOptions struct {
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods.
+	// Zero deletes the pods immediately.
+	GracePeriodSeconds *int64
+
+	// PropagationPolicy tells how the dependents of the evicted pods are deleted,
+	// one of Orphan, Background and Foreground.
+	PropagationPolicy *metav1.DeletionPropagation
+}
+
+// ApprovalWebhook is an HTTP(S) endpoint which allows or denies the evictions of the descheduler.
+type ApprovalWebhook struct {
+	// URL the evictions are sent to for approval.
+	URL string
+
+	// CABundle is the PEM encoded CA bundle the certificate of an HTTPS endpoint is verified with.
+	// The system trust roots are used if it is not set.
+	CABundle []byte
+
+	// Timeout of a single approval request, defaults to 10s.
+	Timeout *metav1.Duration
+
+	// FailurePolicy tells whether the eviction is made when the webhook can not be reached or
+	// answers with an error, Ignore evicts the pod and Fail skips it. Defaults to Fail.
+	FailurePolicy string
 }
 
 // Namespaces carries a list of included/excluded namespaces
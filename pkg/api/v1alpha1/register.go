@@ -34,6 +34,13 @@ const GroupVersion = "v1alpha1"
 // SchemeGroupVersion is group version used to register these objects
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: GroupVersion}
 
+// CustomResourceGroupName is the group the DeschedulerPolicy custom resource is served in.
+// GroupName is not a valid group of a custom resource, which has to contain a dot.
+const CustomResourceGroupName = "descheduler.sigs.k8s.io"
+
+// DeschedulerPolicyResource is the cluster-scoped DeschedulerPolicy custom resource
+var DeschedulerPolicyResource = schema.GroupVersionResource{Group: CustomResourceGroupName, Version: GroupVersion, Resource: "deschedulerpolicies"}
+
 // Kind takes an unqualified kind and returns a Group qualified GroupKind
 func Kind(kind string) schema.GroupKind {
 	return SchemeGroupVersion.WithKind(kind).GroupKind()
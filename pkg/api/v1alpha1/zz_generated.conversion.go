@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "sigs.k8s.io/descheduler/pkg/api"
@@ -35,6 +36,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ApprovalWebhook)(nil), (*api.ApprovalWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ApprovalWebhook_To_api_ApprovalWebhook(a.(*ApprovalWebhook), b.(*api.ApprovalWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ApprovalWebhook)(nil), (*ApprovalWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ApprovalWebhook_To_v1alpha1_ApprovalWebhook(a.(*api.ApprovalWebhook), b.(*ApprovalWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeleteOptions)(nil), (*api.DeleteOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeleteOptions_To_api_DeleteOptions(a.(*DeleteOptions), b.(*api.DeleteOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeleteOptions)(nil), (*DeleteOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeleteOptions_To_v1alpha1_DeleteOptions(a.(*api.DeleteOptions), b.(*DeleteOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicy)(nil), (*api.DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(a.(*DeschedulerPolicy), b.(*api.DeschedulerPolicy), scope)
 	}); err != nil {
@@ -45,6 +66,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicyStatus)(nil), (*api.DeschedulerPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(a.(*DeschedulerPolicyStatus), b.(*api.DeschedulerPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeschedulerPolicyStatus)(nil), (*DeschedulerPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(a.(*api.DeschedulerPolicyStatus), b.(*DeschedulerPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerStrategy)(nil), (*api.DeschedulerStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(a.(*DeschedulerStrategy), b.(*api.DeschedulerStrategy), scope)
 	}); err != nil {
@@ -118,12 +149,74 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_ApprovalWebhook_To_api_ApprovalWebhook(in *ApprovalWebhook, out *api.ApprovalWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = in.FailurePolicy
+	return nil
+}
+
+// Convert_v1alpha1_ApprovalWebhook_To_api_ApprovalWebhook is an autogenerated conversion function.
+func Convert_v1alpha1_ApprovalWebhook_To_api_ApprovalWebhook(in *ApprovalWebhook, out *api.ApprovalWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ApprovalWebhook_To_api_ApprovalWebhook(in, out, s)
+}
+
+func autoConvert_api_ApprovalWebhook_To_v1alpha1_ApprovalWebhook(in *api.ApprovalWebhook, out *ApprovalWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.FailurePolicy = in.FailurePolicy
+	return nil
+}
+
+// Convert_api_ApprovalWebhook_To_v1alpha1_ApprovalWebhook is an autogenerated conversion function.
+func Convert_api_ApprovalWebhook_To_v1alpha1_ApprovalWebhook(in *api.ApprovalWebhook, out *ApprovalWebhook, s conversion.Scope) error {
+	return autoConvert_api_ApprovalWebhook_To_v1alpha1_ApprovalWebhook(in, out, s)
+}
+
+func autoConvert_v1alpha1_DeleteOptions_To_api_DeleteOptions(in *DeleteOptions, out *api.DeleteOptions, s conversion.Scope) error {
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
+	out.PropagationPolicy = (*v1.DeletionPropagation)(unsafe.Pointer(in.PropagationPolicy))
+	return nil
+}
+
+// Convert_v1alpha1_DeleteOptions_To_api_DeleteOptions is an autogenerated conversion function.
+func Convert_v1alpha1_DeleteOptions_To_api_DeleteOptions(in *DeleteOptions, out *api.DeleteOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeleteOptions_To_api_DeleteOptions(in, out, s)
+}
+
+func autoConvert_api_DeleteOptions_To_v1alpha1_DeleteOptions(in *api.DeleteOptions, out *DeleteOptions, s conversion.Scope) error {
+	out.GracePeriodSeconds = (*int64)(unsafe.Pointer(in.GracePeriodSeconds))
+	out.PropagationPolicy = (*v1.DeletionPropagation)(unsafe.Pointer(in.PropagationPolicy))
+	return nil
+}
+
+// Convert_api_DeleteOptions_To_v1alpha1_DeleteOptions is an autogenerated conversion function.
+func Convert_api_DeleteOptions_To_v1alpha1_DeleteOptions(in *api.DeleteOptions, out *DeleteOptions, s conversion.Scope) error {
+	return autoConvert_api_DeleteOptions_To_v1alpha1_DeleteOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
 	out.Strategies = *(*api.StrategyList)(unsafe.Pointer(&in.Strategies))
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxNoOfPodsToEvictPerNamespace = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictPerOwner = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerOwner))
+	out.MaxNoOfPodsToEvictPerZone = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerZone))
+	out.MaxNoOfPodsToEvictTotal = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionsPerSecond = (*float32)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*int)(unsafe.Pointer(in.EvictionBurst))
+	out.EvictionCooldown = (*v1.Duration)(unsafe.Pointer(in.EvictionCooldown))
+	out.ReplacementTimeout = (*v1.Duration)(unsafe.Pointer(in.ReplacementTimeout))
+	out.DeleteOptions = (*api.DeleteOptions)(unsafe.Pointer(in.DeleteOptions))
+	out.ApprovalWebhook = (*api.ApprovalWebhook)(unsafe.Pointer(in.ApprovalWebhook))
+	if err := Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -133,11 +226,25 @@ func Convert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(in *Descheduler
 }
 
 func autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
 	out.Strategies = *(*StrategyList)(unsafe.Pointer(&in.Strategies))
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.EvictLocalStoragePods = (*bool)(unsafe.Pointer(in.EvictLocalStoragePods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxNoOfPodsToEvictPerNamespace = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictPerOwner = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerOwner))
+	out.MaxNoOfPodsToEvictPerZone = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerZone))
+	out.MaxNoOfPodsToEvictTotal = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionsPerSecond = (*float32)(unsafe.Pointer(in.EvictionsPerSecond))
+	out.EvictionBurst = (*int)(unsafe.Pointer(in.EvictionBurst))
+	out.EvictionCooldown = (*v1.Duration)(unsafe.Pointer(in.EvictionCooldown))
+	out.ReplacementTimeout = (*v1.Duration)(unsafe.Pointer(in.ReplacementTimeout))
+	out.DeleteOptions = (*DeleteOptions)(unsafe.Pointer(in.DeleteOptions))
+	out.ApprovalWebhook = (*ApprovalWebhook)(unsafe.Pointer(in.ApprovalWebhook))
+	if err := Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -146,10 +253,37 @@ func Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.Desched
 	return autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in, out, s)
 }
 
+func autoConvert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in *DeschedulerPolicyStatus, out *api.DeschedulerPolicyStatus, s conversion.Scope) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in *DeschedulerPolicyStatus, out *api.DeschedulerPolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in, out, s)
+}
+
+func autoConvert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in *api.DeschedulerPolicyStatus, out *DeschedulerPolicyStatus, s conversion.Scope) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus is an autogenerated conversion function.
+func Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in *api.DeschedulerPolicyStatus, out *DeschedulerPolicyStatus, s conversion.Scope) error {
+	return autoConvert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Weight = in.Weight
 	out.Params = (*api.StrategyParameters)(unsafe.Pointer(in.Params))
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.Schedule = in.Schedule
+	out.Parallelism = in.Parallelism
+	out.DeleteOptions = (*api.DeleteOptions)(unsafe.Pointer(in.DeleteOptions))
+	out.DisruptionMode = in.DisruptionMode
 	return nil
 }
 
@@ -162,6 +296,11 @@ func autoConvert_api_DeschedulerStrategy_To_v1alpha1_DeschedulerStrategy(in *api
 	out.Enabled = in.Enabled
 	out.Weight = in.Weight
 	out.Params = (*StrategyParameters)(unsafe.Pointer(in.Params))
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.Schedule = in.Schedule
+	out.Parallelism = in.Parallelism
+	out.DeleteOptions = (*DeleteOptions)(unsafe.Pointer(in.DeleteOptions))
+	out.DisruptionMode = in.DisruptionMode
 	return nil
 }
 
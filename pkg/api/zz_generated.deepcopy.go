@@ -21,13 +21,67 @@ limitations under the License.
 package api
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalWebhook) DeepCopyInto(out *ApprovalWebhook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalWebhook.
+func (in *ApprovalWebhook) DeepCopy() *ApprovalWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteOptions) DeepCopyInto(out *DeleteOptions) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PropagationPolicy != nil {
+		in, out := &in.PropagationPolicy, &out.PropagationPolicy
+		*out = new(v1.DeletionPropagation)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteOptions.
+func (in *DeleteOptions) DeepCopy() *DeleteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	if in.Strategies != nil {
 		in, out := &in.Strategies, &out.Strategies
 		*out = make(StrategyList, len(*in))
@@ -55,6 +109,57 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxNoOfPodsToEvictPerNamespace != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerNamespace, &out.MaxNoOfPodsToEvictPerNamespace
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictPerOwner != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerOwner, &out.MaxNoOfPodsToEvictPerOwner
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictPerZone != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerZone, &out.MaxNoOfPodsToEvictPerZone
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictTotal != nil {
+		in, out := &in.MaxNoOfPodsToEvictTotal, &out.MaxNoOfPodsToEvictTotal
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvictionsPerSecond != nil {
+		in, out := &in.EvictionsPerSecond, &out.EvictionsPerSecond
+		*out = new(float32)
+		**out = **in
+	}
+	if in.EvictionBurst != nil {
+		in, out := &in.EvictionBurst, &out.EvictionBurst
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvictionCooldown != nil {
+		in, out := &in.EvictionCooldown, &out.EvictionCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReplacementTimeout != nil {
+		in, out := &in.ReplacementTimeout, &out.ReplacementTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeleteOptions != nil {
+		in, out := &in.DeleteOptions, &out.DeleteOptions
+		*out = new(DeleteOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApprovalWebhook != nil {
+		in, out := &in.ApprovalWebhook, &out.ApprovalWebhook
+		*out = new(ApprovalWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -76,6 +181,29 @@ func (in *DeschedulerPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeschedulerPolicyStatus) DeepCopyInto(out *DeschedulerPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerPolicyStatus.
+func (in *DeschedulerPolicyStatus) DeepCopy() *DeschedulerPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulerPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerStrategy) DeepCopyInto(out *DeschedulerStrategy) {
 	*out = *in
@@ -84,6 +212,16 @@ func (in *DeschedulerStrategy) DeepCopyInto(out *DeschedulerStrategy) {
 		*out = new(StrategyParameters)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeleteOptions != nil {
+		in, out := &in.DeleteOptions, &out.DeleteOptions
+		*out = new(DeleteOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -16,8 +16,43 @@ limitations under the License.
 
 package v1alpha1
 
-import "k8s.io/apimachinery/pkg/runtime"
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+func SetDefaults_DeschedulerConfiguration(obj *DeschedulerConfiguration) {
+	if obj.LeaderElection.LeaseDuration.Duration == 0 {
+		obj.LeaderElection.LeaseDuration = metav1.Duration{Duration: 15 * time.Second}
+	}
+	if obj.LeaderElection.RenewDeadline.Duration == 0 {
+		obj.LeaderElection.RenewDeadline = metav1.Duration{Duration: 10 * time.Second}
+	}
+	if obj.LeaderElection.RetryPeriod.Duration == 0 {
+		obj.LeaderElection.RetryPeriod = metav1.Duration{Duration: 2 * time.Second}
+	}
+	if obj.LeaderElection.ResourceLock == "" {
+		obj.LeaderElection.ResourceLock = "leases"
+	}
+	if obj.LeaderElection.ResourceName == "" {
+		obj.LeaderElection.ResourceName = "descheduler"
+	}
+	if obj.LeaderElection.ResourceNamespace == "" {
+		obj.LeaderElection.ResourceNamespace = "kube-system"
+	}
+	if obj.ShutdownTimeout == 0 {
+		obj.ShutdownTimeout = 20 * time.Second
+	}
+	if obj.EvictionHistoryNamespace == "" {
+		obj.EvictionHistoryNamespace = "kube-system"
+	}
+	if obj.EvictionHistoryName == "" {
+		obj.EvictionHistoryName = "descheduler-eviction-history"
+	}
+}
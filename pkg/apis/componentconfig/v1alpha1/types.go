@@ -38,9 +38,22 @@ type DeschedulerConfiguration struct {
 	// PolicyConfigFile is the filepath to the descheduler policy configuration.
 	PolicyConfigFile string `json:"policyConfigFile,omitempty"`
 
+	// PolicyName is the name of the cluster-scoped DeschedulerPolicy custom resource
+	// to use instead of PolicyConfigFile.
+	PolicyName string `json:"policyName,omitempty"`
+
 	// Dry run
 	DryRun bool `json:"dryRun,omitempty"`
 
+	// DryRunReportFormat is the format of the report of the evictions planned in a
+	// descheduling cycle in dry run mode, one of json, yaml and table. No report
+	// is written if it is empty.
+	DryRunReportFormat string `json:"dryRunReportFormat,omitempty"`
+
+	// DryRunReportFile is the file the dry run report is written to, replacing the
+	// report of the previous cycle. The report is written to stdout if it is empty.
+	DryRunReportFile string `json:"dryRunReportFile,omitempty"`
+
 	// Node selectors
 	NodeSelector string `json:"nodeSelector,omitempty"`
 
@@ -56,4 +69,31 @@ type DeschedulerConfiguration struct {
 	// Logging specifies the options of logging.
 	// Refer [Logs Options](https://github.com/kubernetes/component-base/blob/master/logs/options.go) for more information.
 	Logging componentbaseconfig.LoggingConfiguration `json:"logging,omitempty"`
+
+	// LeaderElection starts a leader election client and gains leadership before
+	// running the strategies, so only one of several replicas evicts pods.
+	LeaderElection componentbaseconfig.LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+
+	// MetricsBindAddress is the address the /metrics endpoint is served on.
+	// An empty address disables the endpoint.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+
+	// ShutdownTimeout is how long the descheduler waits for running strategies and
+	// evictions to finish once it is asked to stop, e.g. by SIGTERM.
+	ShutdownTimeout time.Duration `json:"shutdownTimeout,omitempty"`
+
+	// EvictionHistoryNamespace is the namespace of the ConfigMap the evictions are
+	// recorded in by owner when the policy sets an eviction cooldown.
+	EvictionHistoryNamespace string `json:"evictionHistoryNamespace,omitempty"`
+
+	// EvictionHistoryName is the name of the ConfigMap the evictions are recorded in.
+	EvictionHistoryName string `json:"evictionHistoryName,omitempty"`
+
+	// NotificationAuditFile is a file the evictions of each descheduling cycle are
+	// appended to, one JSON object per line. No audit file is written if it is empty.
+	NotificationAuditFile string `json:"notificationAuditFile,omitempty"`
+
+	// NotificationWebhookURL is an HTTP(S) endpoint the evictions of each descheduling
+	// cycle are posted to as a batch. No notifications are sent if it is empty.
+	NotificationWebhookURL string `json:"notificationWebhookURL,omitempty"`
 }
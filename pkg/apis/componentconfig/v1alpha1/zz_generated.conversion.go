@@ -52,12 +52,22 @@ func autoConvert_v1alpha1_DeschedulerConfiguration_To_componentconfig_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyName = in.PolicyName
 	out.DryRun = in.DryRun
+	out.DryRunReportFormat = in.DryRunReportFormat
+	out.DryRunReportFile = in.DryRunReportFile
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
 	out.Logging = in.Logging
+	out.LeaderElection = in.LeaderElection
+	out.MetricsBindAddress = in.MetricsBindAddress
+	out.ShutdownTimeout = time.Duration(in.ShutdownTimeout)
+	out.EvictionHistoryNamespace = in.EvictionHistoryNamespace
+	out.EvictionHistoryName = in.EvictionHistoryName
+	out.NotificationAuditFile = in.NotificationAuditFile
+	out.NotificationWebhookURL = in.NotificationWebhookURL
 	return nil
 }
 
@@ -70,12 +80,22 @@ func autoConvert_componentconfig_DeschedulerConfiguration_To_v1alpha1_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyName = in.PolicyName
 	out.DryRun = in.DryRun
+	out.DryRunReportFormat = in.DryRunReportFormat
+	out.DryRunReportFile = in.DryRunReportFile
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
 	out.Logging = in.Logging
+	out.LeaderElection = in.LeaderElection
+	out.MetricsBindAddress = in.MetricsBindAddress
+	out.ShutdownTimeout = time.Duration(in.ShutdownTimeout)
+	out.EvictionHistoryNamespace = in.EvictionHistoryNamespace
+	out.EvictionHistoryName = in.EvictionHistoryName
+	out.NotificationAuditFile = in.NotificationAuditFile
+	out.NotificationWebhookURL = in.NotificationWebhookURL
 	return nil
 }
 
@@ -29,6 +29,7 @@ func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration)
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.Logging = in.Logging
+	out.LeaderElection = in.LeaderElection
 	return
 }
 
@@ -28,5 +28,10 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_DeschedulerConfiguration(in *DeschedulerConfiguration) {
+	SetDefaults_DeschedulerConfiguration(in)
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"fmt"
 
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
 	// Ensure to load all auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -27,6 +28,26 @@ import (
 )
 
 func CreateClient(kubeconfig string) (clientset.Interface, error) {
+	cfg, err := createConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.NewForConfig(cfg)
+}
+
+// CreateDynamicClient creates a client for the resources without a typed client,
+// e.g. the DeschedulerPolicy custom resource.
+func CreateDynamicClient(kubeconfig string) (dynamic.Interface, error) {
+	cfg, err := createConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.NewForConfig(cfg)
+}
+
+func createConfig(kubeconfig string) (*rest.Config, error) {
 	var cfg *rest.Config
 	if len(kubeconfig) != 0 {
 		master, err := GetMasterFromKubeconfig(kubeconfig)
@@ -47,7 +68,7 @@ func CreateClient(kubeconfig string) (clientset.Interface, error) {
 		}
 	}
 
-	return clientset.NewForConfig(cfg)
+	return cfg, nil
 }
 
 func GetMasterFromKubeconfig(filename string) (string, error) {
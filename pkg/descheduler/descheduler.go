@@ -19,67 +19,365 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
 
-	v1 "k8s.io/api/core/v1"
-	clientset "k8s.io/client-go/kubernetes"
+	"github.com/robfig/cron/v3"
 	"k8s.io/klog/v2"
 
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/metrics"
 )
 
-func Run(rs *options.DeschedulerServer) error {
-	ctx := context.Background()
+// Run runs the descheduler until the context is cancelled, or until the strategies
+// ran once when no DeschedulingInterval is set. Once the context is cancelled no new
+// strategies and evictions are started, and Run waits up to the ShutdownTimeout for
+// the running ones to finish. The notification sinks are notified of the evictions of
+// every cycle, besides the sinks set up by NotificationAuditFile and NotificationWebhookURL.
+func Run(ctx context.Context, rs *options.DeschedulerServer, notificationSinks ...evictions.NotificationSink) error {
 	rsclient, err := client.CreateClient(rs.KubeconfigFile)
 	if err != nil {
 		return err
 	}
 	rs.Client = rsclient
 
-	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile)
+	if len(rs.PolicyConfigFile) > 0 && len(rs.PolicyName) > 0 {
+		return fmt.Errorf("only one of the policy config file and the policy name can be set")
+	}
+
+	if len(rs.DryRunReportFormat) > 0 {
+		if err := evictions.ValidateReportFormat(rs.DryRunReportFormat); err != nil {
+			return err
+		}
+		if !rs.DryRun {
+			klog.InfoS("Dry run report format is set but the descheduler does not run in dry run mode, no report is written")
+		}
+	}
+
+	observers := evictionObservers{notificationSinks: notificationSinks}
+	if len(rs.NotificationAuditFile) > 0 {
+		observers.notificationSinks = append(observers.notificationSinks, evictions.NewAuditFileSink(rs.NotificationAuditFile))
+	}
+	if len(rs.NotificationWebhookURL) > 0 {
+		sink, err := evictions.NewWebhookSink(rs.NotificationWebhookURL)
+		if err != nil {
+			return err
+		}
+		observers.notificationSinks = append(observers.notificationSinks, sink)
+	}
+
+	evictionPolicyGroupVersion, err := eutils.SupportEviction(rs.Client)
 	if err != nil {
 		return err
 	}
-	if deschedulerPolicy == nil {
-		return fmt.Errorf("deschedulerPolicy is nil")
+
+	if len(rs.MetricsBindAddress) > 0 {
+		go func() {
+			klog.V(1).InfoS("Serving metrics", "address", rs.MetricsBindAddress)
+			if err := metrics.ListenAndServe(rs.MetricsBindAddress); err != nil {
+				klog.ErrorS(err, "Unable to serve metrics", "address", rs.MetricsBindAddress)
+			}
+		}()
 	}
 
-	evictionPolicyGroupVersion, err := eutils.SupportEviction(rs.Client)
-	if err != nil || len(evictionPolicyGroupVersion) == 0 {
+	getPolicy, err := loadPolicy(ctx, rs)
+	if err != nil {
 		return err
 	}
+	if len(evictionPolicyGroupVersion) == 0 {
+		if !disruptsWithoutEviction(getPolicy()) {
+			klog.InfoS("The server does not support the eviction subresource, not descheduling")
+			return nil
+		}
+		klog.InfoS("The server does not support the eviction subresource, only strategies with the Delete or RolloutRestart disruption mode can disrupt pods")
+	}
+
+	// a single broadcaster sends the events of all evictions of the process
+	eventRecorder, stopEvents := NewEventRecorder(rs.Client)
+	observers.eventRecorder = eventRecorder
 
-	stopChannel := make(chan struct{})
-	return RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, evictionPolicyGroupVersion, stopChannel)
+	done := make(chan error, 1)
+	go func() {
+		if rs.LeaderElection.LeaderElect {
+			done <- runWithLeaderElection(ctx, rs.Client, rs.LeaderElection, func(ctx context.Context) error {
+				return runDeschedulerStrategies(ctx, rs, getPolicy, evictionPolicyGroupVersion, observers, make(chan struct{}))
+			})
+			return
+		}
+		done <- runDeschedulerStrategies(ctx, rs, getPolicy, evictionPolicyGroupVersion, observers, make(chan struct{}))
+	}()
+
+	select {
+	case err := <-done:
+		stopEvents()
+		return err
+	case <-ctx.Done():
+	}
+	klog.InfoS("Shutting down, waiting for running strategies to finish", "timeout", rs.ShutdownTimeout)
+	select {
+	case err := <-done:
+		stopEvents()
+		return err
+	case <-time.After(rs.ShutdownTimeout):
+		// the events are not stopped, strategies which are still running may record events
+		return fmt.Errorf("running strategies did not finish within the shutdown timeout of %v", rs.ShutdownTimeout)
+	}
 }
 
-type strategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor)
+// loadPolicy returns a function giving the policy for the next descheduling cycle.
+// With a policy name the policy is read from the DeschedulerPolicy custom resource
+// and changes of it are applied live. Otherwise the policy is read from the policy
+// file, which is watched for changes in the continuous mode.
+func loadPolicy(ctx context.Context, rs *options.DeschedulerServer) (func() *api.DeschedulerPolicy, error) {
+	if len(rs.PolicyName) > 0 {
+		dynamicClient, err := client.CreateDynamicClient(rs.KubeconfigFile)
+		if err != nil {
+			return nil, err
+		}
+		policyWatcher := newPolicyResourceWatcher(dynamicClient, rs.PolicyName)
+		if err := policyWatcher.Start(ctx); err != nil {
+			return nil, err
+		}
+		klog.V(1).InfoS("Waiting for a valid DeschedulerPolicy", "policy", rs.PolicyName)
+		if err := wait.PollImmediateUntil(time.Second, func() (bool, error) {
+			return policyWatcher.Policy() != nil, nil
+		}, ctx.Done()); err != nil {
+			return nil, err
+		}
+		return policyWatcher.Policy, nil
+	}
+
+	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if deschedulerPolicy == nil {
+		return nil, fmt.Errorf("deschedulerPolicy is nil")
+	}
+
+	// In the continuous mode changes of the policy file are applied without a restart
+	if rs.DeschedulingInterval.Seconds() > 0 {
+		policyWatcher := newPolicyFileWatcher(rs.PolicyConfigFile, deschedulerPolicy)
+		go policyWatcher.Run(ctx)
+		return policyWatcher.Policy, nil
+	}
+	return func() *api.DeschedulerPolicy { return deschedulerPolicy }, nil
+}
+
+// NewEventRecorder returns a recorder of the eviction events and a func to stop sending them.
+// The broadcaster is not shut down by it, the recorder sends the events to it asynchronously
+// and would panic on a broadcaster shut down right after the last eviction.
+func NewEventRecorder(client clientset.Interface) (record.EventRecorder, func()) {
+	eventBroadcaster := record.NewBroadcaster()
+	logging := eventBroadcaster.StartStructuredLogging(3)
+	recording := eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"}), func() {
+		logging.Stop()
+		recording.Stop()
+	}
+}
+
+// RunDeschedulerStrategies runs the strategies of the policy, the evictions are recorded as events
+// with the eventRecorder, e.g. one of NewEventRecorder. No events are recorded if it is nil.
+func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, eventRecorder record.EventRecorder, stopChannel chan struct{}) error {
+	return runDeschedulerStrategies(ctx, rs, func() *api.DeschedulerPolicy { return deschedulerPolicy }, evictionPolicyGroupVersion, evictionObservers{eventRecorder: eventRecorder}, stopChannel)
+}
+
+// evictionObservers are told about the evictions of every descheduling cycle
+type evictionObservers struct {
+	// eventRecorder records the events of the evictions, no events are recorded if it is nil
+	eventRecorder     record.EventRecorder
+	notificationSinks []evictions.NotificationSink
+}
+
+// pdbCacheSyncTimeout is how long the PodDisruptionBudgets may take to sync before the
+// strategies run without checking them
+const pdbCacheSyncTimeout = time.Minute
+
+// runDeschedulerStrategies runs the strategies of the policy returned by getPolicy.
+// In the continuous mode getPolicy is called before every cycle of the DeschedulingInterval
+// loop, so a new policy takes effect at the next cycle.
+func runDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, getPolicy func() *api.DeschedulerPolicy, evictionPolicyGroupVersion string, observers evictionObservers, stopChannel chan struct{}) error {
+	metrics.Register()
 
-func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, stopChannel chan struct{}) error {
 	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
 	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	// every cycle takes its snapshot of the pods from a shared informer instead of listing them for every node
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+	podLister := podInformer.Lister()
+	var pdbInformer cache.SharedIndexInformer
+	var pdbLister policylisters.PodDisruptionBudgetLister
+	if served, err := eutils.SupportPodDisruptionBudgets(rs.Client); err != nil || !served {
+		klog.InfoS("PodDisruptionBudgets of policy/v1beta1 are not available, evictions are not checked against them before calling the API", "err", err)
+	} else {
+		pdbInformer = sharedInformerFactory.Policy().V1beta1().PodDisruptionBudgets().Informer()
+		pdbLister = sharedInformerFactory.Policy().V1beta1().PodDisruptionBudgets().Lister()
+	}
+	cacheSyncs := []cache.InformerSynced{nodeInformer.Informer().HasSynced, podInformer.Informer().HasSynced}
 
 	sharedInformerFactory.Start(stopChannel)
-	sharedInformerFactory.WaitForCacheSync(stopChannel)
+	cache.WaitForCacheSync(stopChannel, cacheSyncs...)
+	if pdbInformer != nil && !waitForCacheSync(stopChannel, pdbCacheSyncTimeout, pdbInformer.HasSynced) {
+		klog.InfoS("PodDisruptionBudgets did not sync, evictions are not checked against them before calling the API", "timeout", pdbCacheSyncTimeout)
+		pdbLister = nil
+	}
+
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() { close(stopChannel) })
+	}
+	// stop the strategies once the context is cancelled, e.g. when the leader election is lost
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopChannel:
+		}
+	}()
+
+	// runLock makes sure scheduled strategies and the DeschedulingInterval loop
+	// never evict pods at the same time
+	var runLock sync.Mutex
+	runStrategies := func(deschedulerPolicy *api.DeschedulerPolicy, strategyNames []api.StrategyName) {
+		runLock.Lock()
+		defer runLock.Unlock()
+
+		cycleStart := time.Now()
+		defer func() {
+			metrics.CycleDuration.Observe(time.Since(cycleStart).Seconds())
+		}()
+
+		podEvictor, ok := runCycle(ctx, rs, nodeInformer, podLister, pdbLister, deschedulerPolicy, strategyNames, evictionPolicyGroupVersion, observers)
+		if !ok {
+			stop()
+			return
+		}
 
-	strategyFuncs := map[string]strategyFunction{
-		"RemoveDuplicates":                            strategies.RemoveDuplicatePods,
-		"LowNodeUtilization":                          strategies.LowNodeUtilization,
-		"RemovePodsViolatingInterPodAntiAffinity":     strategies.RemovePodsViolatingInterPodAntiAffinity,
-		"RemovePodsViolatingNodeAffinity":             strategies.RemovePodsViolatingNodeAffinity,
-		"RemovePodsViolatingNodeTaints":               strategies.RemovePodsViolatingNodeTaints,
-		"RemovePodsHavingTooManyRestarts":             strategies.RemovePodsHavingTooManyRestarts,
-		"PodLifeTime":                                 strategies.PodLifeTime,
-		"RemovePodsViolatingTopologySpreadConstraint": strategies.RemovePodsViolatingTopologySpreadConstraint,
+		klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+
+		if rs.DryRun && len(rs.DryRunReportFormat) > 0 {
+			if err := writeDryRunReport(rs.DryRunReportFile, rs.DryRunReportFormat, podEvictor.EvictionRecords()); err != nil {
+				klog.ErrorS(err, "Unable to write dry run report", "file", rs.DryRunReportFile)
+			}
+		}
+	}
+
+	// Strategies with their own interval or schedule run independently of the DeschedulingInterval loop
+	scheduler := cron.New(cron.WithLogger(cronLogger{}), cron.WithChain(cron.SkipIfStillRunning(cronLogger{})))
+	scheduledEntries := make(map[api.StrategyName]cron.EntryID)
+	var appliedPolicy *api.DeschedulerPolicy
+	var loopStrategyNames []api.StrategyName
+	applyPolicy := func(deschedulerPolicy *api.DeschedulerPolicy) error {
+		strategyNames := enabledStrategiesByWeight(deschedulerPolicy.Strategies)
+		var loopNames []api.StrategyName
+		strategySchedules := make(map[api.StrategyName]cron.Schedule)
+		for _, name := range strategyNames {
+			schedule, err := strategySchedule(deschedulerPolicy.Strategies[name])
+			if err != nil {
+				return fmt.Errorf("invalid schedule of strategy %q: %v", name, err)
+			}
+			if schedule == nil {
+				loopNames = append(loopNames, name)
+				continue
+			}
+			strategySchedules[name] = schedule
+		}
+		klog.V(1).InfoS("Enabled strategies will run in order of their weight", "strategies", strategyNames)
+
+		for name, id := range scheduledEntries {
+			scheduler.Remove(id)
+			delete(scheduledEntries, name)
+		}
+		for name, schedule := range strategySchedules {
+			name := name
+			klog.V(1).InfoS("Strategy runs on its own schedule", "strategy", name, "interval", deschedulerPolicy.Strategies[name].Interval, "schedule", deschedulerPolicy.Strategies[name].Schedule)
+			scheduledEntries[name] = scheduler.Schedule(schedule, cron.FuncJob(func() {
+				runStrategies(deschedulerPolicy, []api.StrategyName{name})
+			}))
+		}
+		appliedPolicy = deschedulerPolicy
+		loopStrategyNames = loopNames
+		return nil
 	}
 
+	if err := applyPolicy(getPolicy()); err != nil {
+		return err
+	}
+	scheduler.Start()
+	defer func() {
+		// wait for a running strategy to finish
+		<-scheduler.Stop().Done()
+	}()
+
+	// Without a DeschedulingInterval the strategies which are not scheduled run only once,
+	// the scheduled ones keep running until the descheduler is stopped.
+	if rs.DeschedulingInterval.Seconds() == 0 {
+		if len(loopStrategyNames) > 0 || len(scheduledEntries) == 0 {
+			runStrategies(appliedPolicy, loopStrategyNames)
+		}
+		if len(scheduledEntries) == 0 {
+			stop()
+		}
+		<-stopChannel
+		return nil
+	}
+
+	wait.Until(func() {
+		if deschedulerPolicy := getPolicy(); deschedulerPolicy != appliedPolicy {
+			if err := applyPolicy(deschedulerPolicy); err != nil {
+				klog.ErrorS(err, "Unable to apply the new policy, keeping the previous one")
+			}
+		}
+		if len(loopStrategyNames) > 0 || len(scheduledEntries) == 0 {
+			runStrategies(appliedPolicy, loopStrategyNames)
+		}
+	}, rs.DeschedulingInterval, stopChannel)
+
+	return nil
+}
+
+// waitForCacheSync waits for the caches to sync until the stop channel is closed or the timeout passed.
+func waitForCacheSync(stopChannel <-chan struct{}, timeout time.Duration, cacheSyncs ...cache.InformerSynced) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChannel:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return cache.WaitForCacheSync(ctx.Done(), cacheSyncs...)
+}
+
+// runCycle runs the strategies once on the ready nodes and returns the pod evictor
+// the evictions were made with. All strategies work on the same snapshot of the pods
+// taken from the pod lister. Evictions which would exceed a PodDisruptionBudget from
+// the PDB lister are skipped without calling the API. It returns false if the cluster
+// does not have enough ready nodes to evict pods without disruption.
+func runCycle(ctx context.Context, rs *options.DeschedulerServer, nodeInformer coreinformers.NodeInformer, podLister corelisters.PodLister, pdbLister policylisters.PodDisruptionBudgetLister, deschedulerPolicy *api.DeschedulerPolicy, strategyNames []api.StrategyName, evictionPolicyGroupVersion string, observers evictionObservers) (*evictions.PodEvictor, bool) {
 	nodeSelector := rs.NodeSelector
 	if deschedulerPolicy.NodeSelector != nil {
 		nodeSelector = *deschedulerPolicy.NodeSelector
@@ -100,43 +398,196 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 		maxNoOfPodsToEvictPerNode = *deschedulerPolicy.MaxNoOfPodsToEvictPerNode
 	}
 
-	wait.Until(func() {
-		nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, nodeSelector)
+	nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, nodeSelector)
+	if err != nil {
+		klog.V(1).InfoS("Unable to get ready nodes", "err", err)
+		return nil, false
+	}
+
+	if len(nodes) <= 1 {
+		klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
+		return nil, false
+	}
+
+	pods, err := podLister.List(labels.Everything())
+	if err != nil {
+		klog.V(1).InfoS("Unable to list pods", "err", err)
+		return nil, false
+	}
+	ctx = podutil.WithSnapshot(ctx, podutil.NewSnapshot(pods))
+
+	podEvictorOptions := []func(pe *evictions.PodEvictor){
+		evictions.WithEvictionLimits(evictionLimits(deschedulerPolicy)),
+		evictions.WithPodDisruptionBudgets(pdbLister),
+		evictions.WithEventRecorder(observers.eventRecorder),
+	}
+	if deschedulerPolicy.EvictionsPerSecond != nil {
+		burst := 1
+		if deschedulerPolicy.EvictionBurst != nil {
+			burst = *deschedulerPolicy.EvictionBurst
+		}
+		podEvictorOptions = append(podEvictorOptions, evictions.WithRateLimiter(flowcontrol.NewTokenBucketRateLimiter(*deschedulerPolicy.EvictionsPerSecond, burst)))
+	}
+	var history *evictions.EvictionHistory
+	if deschedulerPolicy.EvictionCooldown != nil {
+		history, err = evictions.LoadEvictionHistory(ctx, rs.Client, rs.EvictionHistoryNamespace, rs.EvictionHistoryName)
 		if err != nil {
-			klog.V(1).InfoS("Unable to get ready nodes", "err", err)
-			close(stopChannel)
-			return
+			klog.ErrorS(err, "Unable to load the eviction history, skipping the cycle")
+			return nil, false
+		}
+		podEvictorOptions = append(podEvictorOptions, evictions.WithEvictionHistory(history, deschedulerPolicy.EvictionCooldown.Duration))
+	}
+	if deschedulerPolicy.ReplacementTimeout != nil {
+		podEvictorOptions = append(podEvictorOptions, evictions.WithReplacementWait(deschedulerPolicy.ReplacementTimeout.Duration, podLister))
+	}
+	if webhook := deschedulerPolicy.ApprovalWebhook; webhook != nil {
+		var timeout time.Duration
+		if webhook.Timeout != nil {
+			timeout = webhook.Timeout.Duration
+		}
+		approvalWebhook, err := evictions.NewApprovalWebhook(webhook.URL, webhook.CABundle, timeout, evictions.FailurePolicy(webhook.FailurePolicy))
+		if err != nil {
+			klog.ErrorS(err, "Unable to set up the approval webhook, skipping the cycle")
+			return nil, false
+		}
+		podEvictorOptions = append(podEvictorOptions, evictions.WithApprovalWebhook(approvalWebhook))
+	}
+
+	podEvictor := evictions.NewPodEvictor(
+		rs.Client,
+		evictionPolicyGroupVersion,
+		rs.DryRun,
+		maxNoOfPodsToEvictPerNode,
+		nodes,
+		evictLocalStoragePods,
+		ignorePvcPods,
+		podEvictorOptions...,
+	)
+
+	for _, name := range strategyNames {
+		strategy := deschedulerPolicy.Strategies[name]
+		s, ok := registry.get(name)
+		if !ok {
+			klog.ErrorS(nil, "Unknown strategy, skipping", "strategy", name)
+			continue
 		}
+		if err := s.Validate(strategy.Params); err != nil {
+			klog.ErrorS(err, "Invalid strategy parameters", "strategy", name)
+			continue
+		}
+		strategyStart := time.Now()
+		notEvictable := podEvictor.TotalNotEvictable()
+		strategyCtx := evictions.WithStrategyName(ctx, string(name))
+		strategyCtx = evictions.WithDeleteOptions(strategyCtx, strategyDeleteOptions(deschedulerPolicy, strategy))
+		strategyCtx = evictions.WithDisruptionMode(strategyCtx, evictions.DisruptionMode(strategy.DisruptionMode))
+		s.Run(strategyCtx, rs.Client, strategy, nodes, podEvictor)
+		metrics.StrategyDuration.WithLabelValues(string(name)).Observe(time.Since(strategyStart).Seconds())
+		metrics.PodsNotEvictable.WithLabelValues(string(name)).Add(float64(podEvictor.TotalNotEvictable() - notEvictable))
+	}
+	podEvictor.WaitForDeferredEvictions()
 
-		if len(nodes) <= 1 {
-			klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
-			close(stopChannel)
-			return
+	if history != nil && !rs.DryRun {
+		// the evictions were made, so they are recorded even when descheduling is stopping
+		if err := history.Save(context.Background(), time.Now().Add(-deschedulerPolicy.EvictionCooldown.Duration)); err != nil {
+			klog.ErrorS(err, "Unable to save the eviction history")
+		}
+	}
+	for _, sink := range observers.notificationSinks {
+		if err := sink.Notify(context.Background(), podEvictor.EvictionRecords()); err != nil {
+			klog.ErrorS(err, "Unable to notify about the evictions of the cycle")
 		}
+	}
 
-		podEvictor := evictions.NewPodEvictor(
-			rs.Client,
-			evictionPolicyGroupVersion,
-			rs.DryRun,
-			maxNoOfPodsToEvictPerNode,
-			nodes,
-			evictLocalStoragePods,
-			ignorePvcPods,
-		)
+	return podEvictor, true
+}
 
-		for name, f := range strategyFuncs {
-			if strategy := deschedulerPolicy.Strategies[api.StrategyName(name)]; strategy.Enabled {
-				f(ctx, rs.Client, strategy, nodes, podEvictor)
-			}
+// evictionLimits returns the limits of the policy on top of maxNoOfPodsToEvictPerNode
+func evictionLimits(deschedulerPolicy *api.DeschedulerPolicy) evictions.EvictionLimits {
+	limits := evictions.EvictionLimits{}
+	if deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace != nil {
+		limits.PerNamespace = *deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace
+	}
+	if deschedulerPolicy.MaxNoOfPodsToEvictPerOwner != nil {
+		limits.PerOwner = *deschedulerPolicy.MaxNoOfPodsToEvictPerOwner
+	}
+	if deschedulerPolicy.MaxNoOfPodsToEvictPerZone != nil {
+		limits.PerZone = *deschedulerPolicy.MaxNoOfPodsToEvictPerZone
+	}
+	if deschedulerPolicy.MaxNoOfPodsToEvictTotal != nil {
+		limits.Total = *deschedulerPolicy.MaxNoOfPodsToEvictTotal
+	}
+	return limits
+}
+
+// strategyDeleteOptions returns the options the pods evicted by the strategy are deleted
+// with, the DeleteOptions of the strategy override the ones of the policy
+func strategyDeleteOptions(deschedulerPolicy *api.DeschedulerPolicy, strategy api.DeschedulerStrategy) *metav1.DeleteOptions {
+	deleteOptions := &metav1.DeleteOptions{}
+	for _, options := range []*api.DeleteOptions{deschedulerPolicy.DeleteOptions, strategy.DeleteOptions} {
+		if options == nil {
+			continue
+		}
+		if options.GracePeriodSeconds != nil {
+			deleteOptions.GracePeriodSeconds = options.GracePeriodSeconds
+		}
+		if options.PropagationPolicy != nil {
+			deleteOptions.PropagationPolicy = options.PropagationPolicy
 		}
+	}
+	return deleteOptions
+}
 
-		klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+// writeDryRunReport writes the evictions planned in a descheduling cycle to the
+// file, or to stdout if no file is given
+func writeDryRunReport(file, format string, plannedEvictions []evictions.EvictionRecord) error {
+	report := evictions.DryRunReport{Evictions: plannedEvictions}
+	if len(file) == 0 {
+		return evictions.WriteDryRunReport(os.Stdout, format, report)
+	}
 
-		// If there was no interval specified, send a signal to the stopChannel to end the wait.Until loop after 1 iteration
-		if rs.DeschedulingInterval.Seconds() == 0 {
-			close(stopChannel)
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := evictions.WriteDryRunReport(f, format, report); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// disruptsWithoutEviction tells whether every enabled strategy of the policy disrupts
+// pods in a mode which does not need the eviction subresource
+func disruptsWithoutEviction(deschedulerPolicy *api.DeschedulerPolicy) bool {
+	strategyNames := enabledStrategiesByWeight(deschedulerPolicy.Strategies)
+	if len(strategyNames) == 0 {
+		return false
+	}
+	for _, name := range strategyNames {
+		switch evictions.DisruptionMode(deschedulerPolicy.Strategies[name].DisruptionMode) {
+		case evictions.DisruptionModeRolloutRestart, evictions.DisruptionModeDelete:
+		default:
+			return false
 		}
-	}, rs.DeschedulingInterval, stopChannel)
+	}
+	return true
+}
 
-	return nil
+// enabledStrategiesByWeight returns names of the enabled strategies ordered by
+// their weight, highest first. Strategies with the same weight are ordered by name.
+func enabledStrategiesByWeight(strategyList api.StrategyList) []api.StrategyName {
+	names := make([]api.StrategyName, 0, len(strategyList))
+	for name, strategy := range strategyList {
+		if strategy.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		wi, wj := strategyList[names[i]].Weight, strategyList[names[j]].Weight
+		if wi != wj {
+			return wi > wj
+		}
+		return names[i] < names[j]
+	})
+	return names
 }
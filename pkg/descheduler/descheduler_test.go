@@ -3,16 +3,24 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/test"
 )
 
@@ -45,9 +53,9 @@ func TestTaintsUpdated(t *testing.T) {
 	rs.Client = client
 	rs.DeschedulingInterval = 100 * time.Millisecond
 	go func() {
-		err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", stopChannel)
+		err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, stopChannel)
 		if err != nil {
-			t.Fatalf("Unable to run descheduler strategies: %v", err)
+			t.Errorf("Unable to run descheduler strategies: %v", err)
 		}
 	}()
 
@@ -95,3 +103,395 @@ func TestTaintsUpdated(t *testing.T) {
 		t.Fatalf("Unable to evict pod, node taint did not get propagated to descheduler strategies")
 	}
 }
+
+func TestWaitForCacheSyncTimeout(t *testing.T) {
+	neverSynced := func() bool { return false }
+
+	start := time.Now()
+	if waitForCacheSync(make(chan struct{}), 200*time.Millisecond, neverSynced) {
+		t.Errorf("Expected the cache not to sync")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the wait to end after the timeout, it took %v", elapsed)
+	}
+
+	stopChannel := make(chan struct{})
+	close(stopChannel)
+	if waitForCacheSync(stopChannel, time.Hour, neverSynced) {
+		t.Errorf("Expected the cache not to sync once stopped")
+	}
+}
+
+func TestEnabledStrategiesByWeight(t *testing.T) {
+	strategyList := api.StrategyList{
+		"PodLifeTime":                     api.DeschedulerStrategy{Enabled: true, Weight: 1},
+		"RemoveDuplicates":                api.DeschedulerStrategy{Enabled: true, Weight: 10},
+		"LowNodeUtilization":              api.DeschedulerStrategy{Enabled: true, Weight: 10},
+		"RemovePodsViolatingNodeTaints":   api.DeschedulerStrategy{Enabled: false, Weight: 100},
+		"RemovePodsHavingTooManyRestarts": api.DeschedulerStrategy{Enabled: true},
+	}
+	expected := []api.StrategyName{
+		"LowNodeUtilization",
+		"RemoveDuplicates",
+		"PodLifeTime",
+		"RemovePodsHavingTooManyRestarts",
+	}
+
+	// map iteration order is random, so run a few times to catch nondeterminism
+	for i := 0; i < 10; i++ {
+		if got := enabledStrategiesByWeight(strategyList); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Expected strategies in order %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestDisruptsWithoutEviction(t *testing.T) {
+	tests := []struct {
+		description string
+		strategies  api.StrategyList
+		expected    bool
+	}{
+		{
+			description: "no enabled strategy",
+			strategies:  api.StrategyList{"PodLifeTime": api.DeschedulerStrategy{DisruptionMode: "Delete"}},
+		},
+		{
+			description: "default disruption mode",
+			strategies:  api.StrategyList{"PodLifeTime": api.DeschedulerStrategy{Enabled: true}},
+		},
+		{
+			description: "one strategy evicts",
+			strategies: api.StrategyList{
+				"PodLifeTime":      api.DeschedulerStrategy{Enabled: true, DisruptionMode: "Delete"},
+				"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true, DisruptionMode: "Evict"},
+			},
+		},
+		{
+			description: "every enabled strategy deletes or restarts",
+			strategies: api.StrategyList{
+				"PodLifeTime":        api.DeschedulerStrategy{Enabled: true, DisruptionMode: "Delete"},
+				"RemoveDuplicates":   api.DeschedulerStrategy{Enabled: true, DisruptionMode: "RolloutRestart"},
+				"LowNodeUtilization": api.DeschedulerStrategy{Enabled: false},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := disruptsWithoutEviction(&api.DeschedulerPolicy{Strategies: tc.strategies}); got != tc.expected {
+			t.Errorf("%v: expected %v, got %v", tc.description, tc.expected, got)
+		}
+	}
+}
+
+func TestStrategyDeleteOptions(t *testing.T) {
+	policyGracePeriod, strategyGracePeriod := int64(300), int64(5)
+	background := metav1.DeletePropagationBackground
+	policy := &api.DeschedulerPolicy{
+		DeleteOptions: &api.DeleteOptions{GracePeriodSeconds: &policyGracePeriod, PropagationPolicy: &background},
+	}
+
+	tests := []struct {
+		description string
+		policy      *api.DeschedulerPolicy
+		strategy    api.DeschedulerStrategy
+		expected    *metav1.DeleteOptions
+	}{
+		{
+			description: "no delete options",
+			policy:      &api.DeschedulerPolicy{},
+			expected:    &metav1.DeleteOptions{},
+		},
+		{
+			description: "delete options of the policy",
+			policy:      policy,
+			expected:    &metav1.DeleteOptions{GracePeriodSeconds: &policyGracePeriod, PropagationPolicy: &background},
+		},
+		{
+			description: "strategy overrides the grace period of the policy",
+			policy:      policy,
+			strategy:    api.DeschedulerStrategy{DeleteOptions: &api.DeleteOptions{GracePeriodSeconds: &strategyGracePeriod}},
+			expected:    &metav1.DeleteOptions{GracePeriodSeconds: &strategyGracePeriod, PropagationPolicy: &background},
+		},
+	}
+
+	for _, tc := range tests {
+		if got := strategyDeleteOptions(tc.policy, tc.strategy); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%v: expected delete options %v, got %v", tc.description, tc.expected, got)
+		}
+	}
+}
+
+func TestStrategiesRunInWeightOrder(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var order []api.StrategyName
+	recorder := func(name api.StrategyName) Strategy {
+		return NewStrategy(name, nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+			order = append(order, name)
+		})
+	}
+	for _, name := range []api.StrategyName{"WeightOrderA", "WeightOrderB", "WeightOrderC"} {
+		if err := RegisterStrategy(recorder(name)); err != nil {
+			t.Fatalf("Unable to register strategy: %v", err)
+		}
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"WeightOrderA": api.DeschedulerStrategy{Enabled: true, Weight: 1},
+			"WeightOrderB": api.DeschedulerStrategy{Enabled: true, Weight: 5},
+			"WeightOrderC": api.DeschedulerStrategy{Enabled: true, Weight: 1},
+		},
+	}
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	expected := []api.StrategyName{"WeightOrderB", "WeightOrderA", "WeightOrderC"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected strategies to run in order %v, got %v", expected, order)
+	}
+}
+
+func TestNoEvictionsAfterContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+	p2 := test.BuildTestPod("p2", 100, 0, n1.Name, nil)
+
+	client := fakeclientset.NewSimpleClientset(n1, n2, p1, p2)
+	runs := 0
+	var evicted []string
+	err := RegisterStrategy(NewStrategy("CancelledContext", nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+		runs++
+		for _, pod := range []*v1.Pod{p1, p2} {
+			success, err := podEvictor.EvictPod(ctx, pod, n1)
+			if err != nil {
+				break
+			}
+			if success {
+				evicted = append(evicted, pod.Name)
+			}
+			// shutdown begins while the strategy is running
+			cancel()
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Unable to register strategy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = client
+	rs.DeschedulingInterval = 10 * time.Millisecond
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{"CancelledContext": api.DeschedulerStrategy{Enabled: true}},
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, make(chan struct{}))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unable to run descheduler strategies: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Descheduler did not stop after the context was cancelled")
+	}
+
+	if runs != 1 {
+		t.Errorf("Expected the strategy to run once, got %v runs", runs)
+	}
+	if !reflect.DeepEqual(evicted, []string{"p1"}) {
+		t.Errorf("Expected only the eviction started before the cancellation, got %v", evicted)
+	}
+}
+
+func TestStrategiesShareCycleSnapshot(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+	p2 := test.BuildTestPod("p2", 100, 0, n1.Name, nil)
+
+	seen := make(map[api.StrategyName][]string)
+	record := func(name api.StrategyName, evict bool) Strategy {
+		return NewStrategy(name, nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+			pods, err := podutil.ListPodsOnANode(ctx, client, n1)
+			if err != nil {
+				t.Errorf("Unable to list pods: %v", err)
+				return
+			}
+			for _, pod := range pods {
+				seen[name] = append(seen[name], pod.Name)
+			}
+			if evict {
+				if _, err := podEvictor.EvictPod(ctx, p1, n1); err != nil {
+					t.Errorf("Unable to evict pod: %v", err)
+				}
+			}
+		})
+	}
+	for _, strategy := range []Strategy{record("SnapshotFirst", true), record("SnapshotSecond", false)} {
+		if err := RegisterStrategy(strategy); err != nil {
+			t.Fatalf("Unable to register strategy: %v", err)
+		}
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2, p1, p2)
+	rs.DryRun = true
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"SnapshotFirst":  api.DeschedulerStrategy{Enabled: true, Weight: 2},
+			"SnapshotSecond": api.DeschedulerStrategy{Enabled: true, Weight: 1},
+		},
+	}
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	expected := map[api.StrategyName][]string{
+		"SnapshotFirst":  {"p1", "p2"},
+		"SnapshotSecond": {"p2"},
+	}
+	for name, pods := range seen {
+		sort.Strings(pods)
+		seen[name] = pods
+	}
+	if !reflect.DeepEqual(seen, expected) {
+		t.Errorf("Expected strategies to see pods %v, got %v", expected, seen)
+	}
+}
+
+type recordingSink struct {
+	batches [][]evictions.EvictionRecord
+}
+
+func (s *recordingSink) Notify(ctx context.Context, records []evictions.EvictionRecord) error {
+	s.batches = append(s.batches, records)
+	return nil
+}
+
+func TestNotificationSinks(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, test.SetRSOwnerRef)
+
+	err := RegisterStrategy(NewStrategy("NotifyEvictions", nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+		if _, err := podEvictor.EvictPod(ctx, p1, n1, "TestReason"); err != nil {
+			t.Errorf("Unable to evict pod: %v", err)
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Unable to register strategy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2, p1)
+	rs.DryRun = true
+	sink := &recordingSink{}
+	observers := evictionObservers{notificationSinks: []evictions.NotificationSink{sink}}
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{"NotifyEvictions": api.DeschedulerStrategy{Enabled: true}},
+	}
+	getPolicy := func() *api.DeschedulerPolicy { return dp }
+	if err := runDeschedulerStrategies(ctx, rs, getPolicy, "v1beta1", observers, make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	if len(sink.batches) != 1 || len(sink.batches[0]) != 1 {
+		t.Fatalf("Expected a single batch with a single eviction, got %v", sink.batches)
+	}
+	record := sink.batches[0][0]
+	record.Time = time.Time{}
+	expected := evictions.EvictionRecord{
+		Namespace:      "default",
+		Pod:            "p1",
+		Owner:          "ReplicaSet/replicaset-1",
+		Node:           "n1",
+		Strategy:       "NotifyEvictions",
+		Reason:         "TestReason",
+		DisruptionMode: evictions.DisruptionModeEvict,
+		DryRun:         true,
+	}
+	if record != expected {
+		t.Errorf("Expected eviction record %+v, got %+v", expected, record)
+	}
+}
+
+func TestRunDeschedulerStrategiesRecordsEvents(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+
+	err := RegisterStrategy(NewStrategy("RecordEvents", nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+		if _, err := podEvictor.EvictPod(ctx, p1, n1); err != nil {
+			t.Errorf("Unable to evict pod: %v", err)
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Unable to register strategy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	client := fakeclientset.NewSimpleClientset(n1, n2, p1)
+	// the fake clientset rejects events created in the namespace of the event rather than of the client
+	var lock sync.Mutex
+	var events []string
+	client.PrependReactor("create", "events", func(action core.Action) (bool, runtime.Object, error) {
+		event := action.(core.CreateAction).GetObject().(*v1.Event)
+		lock.Lock()
+		events = append(events, event.InvolvedObject.Kind+"/"+event.InvolvedObject.Name)
+		lock.Unlock()
+		return true, event, nil
+	})
+	rs.Client = client
+	eventRecorder, stopEvents := NewEventRecorder(client)
+	defer stopEvents()
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{"RecordEvents": api.DeschedulerStrategy{Enabled: true}},
+	}
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", eventRecorder, make(chan struct{})); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	// the events are sent to the API server asynchronously
+	if err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		for _, event := range events {
+			if event == "Pod/"+p1.Name {
+				return true, nil
+			}
+		}
+		return false, nil
+	}); err != nil {
+		t.Errorf("Expected an event of the eviction of pod %v: %v", p1.Name, err)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// DisruptionMode is how the pods a strategy selects are disrupted
+type DisruptionMode string
+
+const (
+	// DisruptionModeEvict evicts the pods through the eviction subresource, it is the default
+	DisruptionModeEvict DisruptionMode = "Evict"
+	// DisruptionModeRolloutRestart restarts the Deployment, StatefulSet or DaemonSet owning
+	// the pods, so the pods are replaced within the maxSurge and maxUnavailable of the workload
+	DisruptionModeRolloutRestart DisruptionMode = "RolloutRestart"
+	// DisruptionModeDelete deletes the pods, e.g. on clusters without the eviction subresource.
+	// PodDisruptionBudgets are only checked by the descheduler, see WithPodDisruptionBudgets.
+	DisruptionModeDelete DisruptionMode = "Delete"
+)
+
+// DisruptionModes are the supported disruption modes
+var DisruptionModes = []DisruptionMode{DisruptionModeEvict, DisruptionModeRolloutRestart, DisruptionModeDelete}
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout restart` sets
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// verb tells what happens to a pod disrupted in the mode
+func (mode DisruptionMode) verb() string {
+	switch mode {
+	case DisruptionModeRolloutRestart:
+		return "replaced by a rollout restart"
+	case DisruptionModeDelete:
+		return "deleted"
+	default:
+		return "evicted"
+	}
+}
+
+type disruptionModeKey struct{}
+
+// WithDisruptionMode returns a copy of ctx carrying the mode the pods evicted with the context are disrupted in
+func WithDisruptionMode(ctx context.Context, mode DisruptionMode) context.Context {
+	return context.WithValue(ctx, disruptionModeKey{}, mode)
+}
+
+func disruptionMode(ctx context.Context) DisruptionMode {
+	if mode, ok := ctx.Value(disruptionModeKey{}).(DisruptionMode); ok && mode != "" {
+		return mode
+	}
+	return DisruptionModeEvict
+}
+
+// errWorkloadRestarted is returned for the pods of a workload which was restarted in the cycle already,
+// they are replaced by the running rollout and not disrupted on their own
+var errWorkloadRestarted = errors.New("workload of the pod was restarted in the cycle already")
+
+// disrupt evicts, deletes or restarts the workload of the pod as the mode of the context tells
+func (pe *PodEvictor) disrupt(ctx context.Context, pod *v1.Pod) error {
+	mode := disruptionMode(ctx)
+	if mode == DisruptionModeRolloutRestart {
+		return pe.restartWorkload(ctx, pod)
+	}
+	if pe.dryRun {
+		return nil
+	}
+	switch mode {
+	case DisruptionModeEvict:
+		if len(pe.policyGroupVersion) == 0 {
+			return fmt.Errorf("unable to evict pod %q, the server does not support the eviction subresource", pod.Name)
+		}
+		return evictPod(ctx, pe.client, pod, pe.policyGroupVersion, deleteOptions(ctx), pe.dryRun, pe.evictionFunc)
+	case DisruptionModeDelete:
+		return deletePod(ctx, pe.client, pod, deleteOptions(ctx))
+	default:
+		return fmt.Errorf("unknown disruption mode %q", mode)
+	}
+}
+
+func deletePod(ctx context.Context, client clientset.Interface, pod *v1.Pod, deleteOptions *metav1.DeleteOptions) error {
+	err := client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, *deleteOptions)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("pod not found when deleting %q: %w", pod.Name, err)
+	}
+	return err
+}
+
+// restartWorkload restarts the Deployment, StatefulSet or DaemonSet owning the pod the same way
+// `kubectl rollout restart` does. A workload is restarted once per cycle, errWorkloadRestarted is
+// returned for the pods of a workload which was restarted already. In dry run mode the workload
+// is looked up the same way, but not patched.
+func (pe *PodEvictor) restartWorkload(ctx context.Context, pod *v1.Pod) error {
+	kind, name, err := workloadOf(ctx, pe.client, pod)
+	if err != nil {
+		return err
+	}
+	key := pod.Namespace + "/" + kind + "/" + name
+
+	pe.lock.Lock()
+	restarted := pe.restartedWorkloads[key]
+	pe.restartedWorkloads[key] = true
+	pe.lock.Unlock()
+	if restarted {
+		return errWorkloadRestarted
+	}
+	if pe.dryRun {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "Deployment":
+		_, err = pe.client.AppsV1().Deployments(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = pe.client.AppsV1().StatefulSets(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = pe.client.AppsV1().DaemonSets(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		pe.lock.Lock()
+		delete(pe.restartedWorkloads, key)
+		pe.lock.Unlock()
+		return fmt.Errorf("unable to restart %v %q of pod %q: %w", kind, name, pod.Name, err)
+	}
+	return nil
+}
+
+// workloadOf returns the kind and name of the workload which can be restarted to replace the pod
+func workloadOf(ctx context.Context, client clientset.Interface, pod *v1.Pod) (string, string, error) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return "", "", fmt.Errorf("pod %q has no controller to restart", pod.Name)
+	}
+	switch ownerRef.Kind {
+	case "StatefulSet", "DaemonSet":
+		return ownerRef.Kind, ownerRef.Name, nil
+	case "ReplicaSet":
+		rs, err := client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ownerRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", "", fmt.Errorf("unable to get ReplicaSet %q of pod %q: %w", ownerRef.Name, pod.Name, err)
+		}
+		if rsOwnerRef := metav1.GetControllerOf(rs); rsOwnerRef != nil && rsOwnerRef.Kind == "Deployment" {
+			return rsOwnerRef.Kind, rsOwnerRef.Name, nil
+		}
+		return "", "", fmt.Errorf("ReplicaSet %q of pod %q is not owned by a Deployment", ownerRef.Name, pod.Name)
+	default:
+		return "", "", fmt.Errorf("%v %q of pod %q can not be restarted", ownerRef.Kind, ownerRef.Name, pod.Name)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func controlledBy(kind, name string) func(pod *v1.Pod) {
+	return func(pod *v1.Pod) {
+		isController := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+}
+
+func TestEvictPodDisruptionModeDelete(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 100, 0, "node1", nil)
+	fakeClient := fake.NewSimpleClientset(pod1)
+
+	// the Delete mode works on servers without the eviction subresource
+	podEvictor := NewPodEvictor(fakeClient, "", false, 0, []*v1.Node{node1}, false, false)
+	ctx := WithDisruptionMode(context.Background(), DisruptionModeDelete)
+	if success, err := podEvictor.EvictPod(ctx, pod1, node1); !success || err != nil {
+		t.Fatalf("Expected pod %v to be deleted, got %v, %v", pod1.Name, success, err)
+	}
+	if _, err := fakeClient.CoreV1().Pods(pod1.Namespace).Get(ctx, pod1.Name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected pod %v to be deleted, got %v", pod1.Name, err)
+	}
+	for _, action := range fakeClient.Actions() {
+		if action.GetSubresource() == "eviction" {
+			t.Errorf("Expected no eviction, got %v", action)
+		}
+	}
+
+	pod2 := test.BuildTestPod("p2", 100, 0, "node1", nil)
+	if success, err := podEvictor.EvictPod(context.Background(), pod2, node1); success || err != nil {
+		t.Errorf("Expected pod %v not to be evicted without the eviction subresource, got %v, %v", pod2.Name, success, err)
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 pod to be disrupted, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodDisruptionModeRolloutRestart(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	isController := true
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "web-1234",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}},
+	}}
+	// a rollout of the Deployment in progress, with pods of the old and the new ReplicaSet
+	newRS := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "web-5678",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}},
+	}}
+	bareRS := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bare"}}
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"}}
+	fakeClient := fake.NewSimpleClientset(deployment, rs, newRS, bareRS, sts)
+	podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 0, []*v1.Node{node1}, false, false)
+	ctx := WithDisruptionMode(context.Background(), DisruptionModeRolloutRestart)
+
+	for _, tc := range []struct {
+		pod     *v1.Pod
+		success bool
+	}{
+		{pod: test.BuildTestPod("web-1", 100, 0, "node1", controlledBy("ReplicaSet", "web-1234")), success: true},
+		// the Deployment is restarted once, the rollout replaces its other pods
+		{pod: test.BuildTestPod("web-2", 100, 0, "node1", controlledBy("ReplicaSet", "web-1234"))},
+		{pod: test.BuildTestPod("db-0", 100, 0, "node1", controlledBy("StatefulSet", "db")), success: true},
+		{pod: test.BuildTestPod("bare-1", 100, 0, "node1", controlledBy("ReplicaSet", "bare"))},
+		{pod: test.BuildTestPod("job-1", 100, 0, "node1", controlledBy("Job", "job"))},
+		{pod: test.BuildTestPod("standalone", 100, 0, "node1", nil)},
+	} {
+		if success, err := podEvictor.EvictPod(ctx, tc.pod, node1); success != tc.success || err != nil {
+			t.Errorf("Expected pod %v to be disrupted %v, got %v, %v", tc.pod.Name, tc.success, success, err)
+		}
+	}
+	if podEvictor.TotalEvicted() != 2 {
+		t.Errorf("Expected 2 pods to be disrupted, got %v", podEvictor.TotalEvicted())
+	}
+	if records := podEvictor.EvictionRecords(); len(records) != 2 {
+		t.Errorf("Expected 2 eviction records, got %v", records)
+	}
+
+	patches := map[string]int{}
+	for _, action := range fakeClient.Actions() {
+		switch action := action.(type) {
+		case core.PatchAction:
+			patches[action.GetResource().Resource+"/"+action.GetName()]++
+		case core.CreateAction:
+			if action.GetSubresource() == "eviction" {
+				t.Errorf("Expected no eviction, got %v", action)
+			}
+		}
+	}
+	if patches["deployments/web"] != 1 || patches["statefulsets/db"] != 1 || len(patches) != 2 {
+		t.Errorf("Expected the Deployment and the StatefulSet to be patched once, got %v", patches)
+	}
+
+	updated, err := fakeClient.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get Deployment: %v", err)
+	}
+	if _, ok := updated.Spec.Template.Annotations[restartedAtAnnotation]; !ok {
+		t.Errorf("Expected the pod template of the Deployment to be annotated with %v, got %v", restartedAtAnnotation, updated.Spec.Template.Annotations)
+	}
+
+	// in dry run mode the workloads are looked up the same way, only the first pod of a workload
+	// is counted and the pods of other controllers are not restartable
+	fakeClient.ClearActions()
+	dryRunEvictor := NewPodEvictor(fakeClient, "v1beta1", true, 0, []*v1.Node{node1}, false, false)
+	for _, tc := range []struct {
+		pod     *v1.Pod
+		success bool
+	}{
+		{pod: test.BuildTestPod("web-1", 100, 0, "node1", controlledBy("ReplicaSet", "web-1234")), success: true},
+		{pod: test.BuildTestPod("web-2", 100, 0, "node1", controlledBy("ReplicaSet", "web-5678"))},
+		{pod: test.BuildTestPod("bare-1", 100, 0, "node1", controlledBy("ReplicaSet", "bare"))},
+		{pod: test.BuildTestPod("job-1", 100, 0, "node1", controlledBy("Job", "job"))},
+		{pod: test.BuildTestPod("unknown-1", 100, 0, "node1", controlledBy("ReplicaSet", "unknown"))},
+	} {
+		if success, err := dryRunEvictor.EvictPod(ctx, tc.pod, node1); success != tc.success || err != nil {
+			t.Errorf("Expected pod %v to be disrupted %v in dry run mode, got %v, %v", tc.pod.Name, tc.success, success, err)
+		}
+	}
+	if dryRunEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 pod to be disrupted in dry run mode, got %v", dryRunEvictor.TotalEvicted())
+	}
+	for _, action := range fakeClient.Actions() {
+		if _, ok := action.(core.PatchAction); ok {
+			t.Errorf("Expected no patch in dry run mode, got %v", action)
+		}
+	}
+}
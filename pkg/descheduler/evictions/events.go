@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// WithEventRecorder emits an event on the evicted pod, its owning workload and its node
+// for every eviction. Evictions in dry run mode are reported as WouldDescheduled events.
+func WithEventRecorder(recorder record.EventRecorder) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.eventRecorder = recorder
+	}
+}
+
+// recordEvents emits the events of an eviction of the pod
+func (pe *PodEvictor) recordEvents(ctx context.Context, pod *v1.Pod, node *v1.Node, reason string) {
+	if pe.eventRecorder == nil {
+		return
+	}
+	eventReason, verb := "Descheduled", disruptionMode(ctx).verb()
+	if pe.dryRun {
+		eventReason, verb = "WouldDescheduled", "would be "+verb
+	}
+
+	pe.eventRecorder.Event(pod, v1.EventTypeNormal, eventReason, fmt.Sprintf("pod %s by sigs.k8s.io/descheduler%s", verb, reason))
+	message := fmt.Sprintf("pod %s/%s %s by sigs.k8s.io/descheduler%s", pod.Namespace, pod.Name, verb, reason)
+	if owner := pe.eventOwner(ctx, pod); owner != nil {
+		pe.eventRecorder.Event(owner, v1.EventTypeNormal, eventReason, message)
+	}
+	pe.eventRecorder.Event(&v1.ObjectReference{Kind: "Node", Name: node.Name, UID: node.UID}, v1.EventTypeNormal, eventReason, message)
+}
+
+// eventOwner returns the workload the events of an eviction of the pod are attached to. For
+// the pods of a ReplicaSet owned by a Deployment it is the Deployment, so the evictions show
+// up when describing the Deployment. It returns nil for pods without owners.
+func (pe *PodEvictor) eventOwner(ctx context.Context, pod *v1.Pod) *v1.ObjectReference {
+	ownerRef := podOwner(pod)
+	if ownerRef == nil {
+		return nil
+	}
+	if ownerRef.Kind == "ReplicaSet" {
+		rs, err := pe.client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ownerRef.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(3).InfoS("Unable to get ReplicaSet of pod, attaching the events to the ReplicaSet", "pod", klog.KObj(pod), "replicaSet", ownerRef.Name, "err", err)
+		} else if rsOwnerRef := metav1.GetControllerOf(rs); rsOwnerRef != nil && rsOwnerRef.Kind == "Deployment" {
+			ownerRef = rsOwnerRef
+		}
+	}
+	return &v1.ObjectReference{
+		APIVersion: ownerRef.APIVersion,
+		Kind:       ownerRef.Kind,
+		Namespace:  pod.Namespace,
+		Name:       ownerRef.Name,
+		UID:        ownerRef.UID,
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+// testRecorder keeps the kind and name of the object, the reason and the message of every event
+type testRecorder struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (r *testRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	var kind, name string
+	switch object := object.(type) {
+	case *v1.ObjectReference:
+		kind, name = object.Kind, object.Name
+	case *v1.Pod:
+		kind, name = "Pod", object.Name
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, fmt.Sprintf("%s/%s %s %s: %s", kind, name, eventtype, reason, message))
+}
+
+func (r *testRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *testRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestEvictPodEvents(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	isController := true
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "web-1234",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}},
+	}}
+
+	tests := []struct {
+		description string
+		dryRun      bool
+		pod         *v1.Pod
+		expected    []string
+	}{
+		{
+			description: "events on the pod, the Deployment and the node",
+			pod:         test.BuildTestPod("web-1", 100, 0, "node1", controlledBy("ReplicaSet", "web-1234")),
+			expected: []string{
+				"Pod/web-1 Normal Descheduled: pod evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Deployment/web Normal Descheduled: pod default/web-1 evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Node/node1 Normal Descheduled: pod default/web-1 evicted by sigs.k8s.io/descheduler (TestReason)",
+			},
+		},
+		{
+			description: "events on the owner if it is not a Deployment",
+			pod:         test.BuildTestPod("db-0", 100, 0, "node1", controlledBy("StatefulSet", "db")),
+			expected: []string{
+				"Pod/db-0 Normal Descheduled: pod evicted by sigs.k8s.io/descheduler (TestReason)",
+				"StatefulSet/db Normal Descheduled: pod default/db-0 evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Node/node1 Normal Descheduled: pod default/db-0 evicted by sigs.k8s.io/descheduler (TestReason)",
+			},
+		},
+		{
+			description: "no owner event for pods without owner",
+			pod:         test.BuildTestPod("standalone", 100, 0, "node1", nil),
+			expected: []string{
+				"Pod/standalone Normal Descheduled: pod evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Node/node1 Normal Descheduled: pod default/standalone evicted by sigs.k8s.io/descheduler (TestReason)",
+			},
+		},
+		{
+			description: "WouldDescheduled events in dry run mode",
+			dryRun:      true,
+			pod:         test.BuildTestPod("web-2", 100, 0, "node1", controlledBy("ReplicaSet", "web-1234")),
+			expected: []string{
+				"Pod/web-2 Normal WouldDescheduled: pod would be evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Deployment/web Normal WouldDescheduled: pod default/web-2 would be evicted by sigs.k8s.io/descheduler (TestReason)",
+				"Node/node1 Normal WouldDescheduled: pod default/web-2 would be evicted by sigs.k8s.io/descheduler (TestReason)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			recorder := &testRecorder{}
+			fakeClient := fake.NewSimpleClientset(rs, tc.pod)
+			podEvictor := NewPodEvictor(fakeClient, "v1beta1", tc.dryRun, 0, []*v1.Node{node1}, false, false, WithEventRecorder(recorder))
+			if success, err := podEvictor.EvictPod(ctx, tc.pod, node1, "TestReason"); !success || err != nil {
+				t.Fatalf("Expected pod %v to be evicted, got %v, %v", tc.pod.Name, success, err)
+			}
+			if !reflect.DeepEqual(recorder.events, tc.expected) {
+				t.Errorf("Expected events %v, got %v", tc.expected, recorder.events)
+			}
+		})
+	}
+}
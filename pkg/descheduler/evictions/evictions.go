@@ -18,20 +18,29 @@ package evictions
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	policy "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/metrics"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
@@ -41,17 +50,128 @@ const (
 	evictPodAnnotationKey = "descheduler.alpha.kubernetes.io/evict"
 )
 
+var (
+	// ErrNodeLimitReached is returned when no more pods can be evicted from the node,
+	// pods on other nodes can still be evicted
+	ErrNodeLimitReached = goerrors.New("maximum number of evicted pods per node reached")
+	// ErrZoneLimitReached is returned when no more pods can be evicted from the nodes
+	// of the zone, pods on nodes in other zones can still be evicted
+	ErrZoneLimitReached = goerrors.New("maximum number of evicted pods per zone reached")
+	// ErrNamespaceLimitReached is returned when no more pods can be evicted from the
+	// namespace of the pod, other pods on the same node can still be evicted
+	ErrNamespaceLimitReached = goerrors.New("maximum number of evicted pods per namespace reached")
+	// ErrOwnerLimitReached is returned when no more pods of the owner of the pod can be
+	// evicted, other pods on the same node can still be evicted
+	ErrOwnerLimitReached = goerrors.New("maximum number of evicted pods per owner reached")
+	// ErrOwnerCooldown is returned when a pod of the workload of the pod was evicted within
+	// the eviction cooldown, other pods on the same node can still be evicted
+	ErrOwnerCooldown = goerrors.New("pods of the workload were evicted within the cooldown")
+	// ErrPodDisruptionBudget is returned when the eviction of the pod would exceed the disruptions
+	// a PodDisruptionBudget allows, other pods on the same node can still be evicted
+	ErrPodDisruptionBudget = goerrors.New("eviction would violate a pod disruption budget")
+	// ErrEvictionDeferred is returned when the eviction of the pod is deferred until the replacement
+	// of an earlier evicted pod of its owner is ready, see WithReplacementWait. The pod is not evicted
+	// yet, and may not be if the replacement is not ready in time, other pods on the same node can
+	// still be evicted
+	ErrEvictionDeferred = goerrors.New("eviction deferred until the replacement of an earlier evicted pod is ready")
+	// ErrTotalLimitReached is returned when no more pods can be evicted in the descheduling cycle
+	ErrTotalLimitReached = goerrors.New("maximum number of evicted pods in total reached")
+)
+
+// IsPodLimitError tells whether the error only prevents the eviction of the given pod,
+// so the strategy can go on with the other pods of the node.
+func IsPodLimitError(err error) bool {
+	return goerrors.Is(err, ErrNamespaceLimitReached) || goerrors.Is(err, ErrOwnerLimitReached) ||
+		goerrors.Is(err, ErrOwnerCooldown) || goerrors.Is(err, ErrPodDisruptionBudget) || goerrors.Is(err, ErrEvictionDeferred)
+}
+
+// IsStopError tells whether no more pods can be evicted at all, because the
+// total limit is reached or descheduling is stopping.
+func IsStopError(err error) bool {
+	return goerrors.Is(err, ErrTotalLimitReached) || goerrors.Is(err, context.Canceled) || goerrors.Is(err, context.DeadlineExceeded)
+}
+
 // nodePodEvictedCount keeps count of pods evicted on node
 type nodePodEvictedCount map[*v1.Node]int
 
+// EvictionLimits caps the number of pods evicted in a descheduling cycle on top of
+// the limit per node. A limit of 0 means no limit.
+type EvictionLimits struct {
+	// PerNamespace is the maximum number of pods evicted from a namespace
+	PerNamespace int
+	// PerOwner is the maximum number of pods evicted from the same owning workload
+	PerOwner int
+	// PerZone is the maximum number of pods evicted from the nodes of a topology zone
+	PerZone int
+	// Total is the maximum number of pods evicted in the cycle
+	Total int
+}
+
+// WithRateLimiter spreads the evictions over time, every eviction waits for the rate limiter.
+// Evictions in dry run mode are not rate limited.
+func WithRateLimiter(rateLimiter flowcontrol.RateLimiter) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.rateLimiter = rateLimiter
+	}
+}
+
+// WithEvictionHistory skips the pods of workloads which had a pod evicted within the cooldown,
+// according to the history or earlier in the cycle. The workload of the pods of a ReplicaSet
+// is its Deployment. Evictions are recorded in the history, except in dry run mode.
+func WithEvictionHistory(history *EvictionHistory, cooldown time.Duration) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.history = history
+		pe.cooldown = cooldown
+	}
+}
+
+// WithEvictionLimits sets the limits the pod evictor enforces on top of maxPodsToEvictPerNode
+func WithEvictionLimits(limits EvictionLimits) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.limits = limits
+	}
+}
+
+// PodEvictor evicts pods on behalf of the strategies. It is safe for concurrent use,
+// so a strategy can process several nodes at the same time.
 type PodEvictor struct {
 	client                clientset.Interface
 	policyGroupVersion    string
 	dryRun                bool
 	maxPodsToEvictPerNode int
-	nodepodCount          nodePodEvictedCount
 	evictLocalStoragePods bool
 	ignorePvcPods         bool
+	limits                EvictionLimits
+	rateLimiter           flowcontrol.RateLimiter
+	history               *EvictionHistory
+	cooldown              time.Duration
+	pdbLister             policylisters.PodDisruptionBudgetLister
+	replacementTimeout    time.Duration
+	podLister             corelisters.PodLister
+	approvalWebhook       *ApprovalWebhook
+	eventRecorder         record.EventRecorder
+	evictionFunc          EvictionFunc
+	// deferred waits for the evictions deferred until a replacement pod is ready
+	deferred sync.WaitGroup
+
+	// lock protects the counters and the eviction records
+	lock           sync.Mutex
+	nodepodCount   nodePodEvictedCount
+	namespaceCount map[string]int
+	ownerCount     map[string]int
+	zoneCount      map[string]int
+	// cooldownCount counts the evictions of the cycle by workload, see workloadKey
+	cooldownCount map[string]int
+	// workloads caches the workload of every ReplicaSet seen in the cycle, see resolveWorkload
+	workloads map[string]string
+	// disruptionsAllowed keeps the disruptions left of every budget seen in the cycle
+	disruptionsAllowed map[types.NamespacedName]int32
+	pacedOwners        map[string]*pacedOwner
+	// deferralDeadline is when the deferred evictions of the cycle are given up
+	deferralDeadline   time.Time
+	restartedWorkloads map[string]bool
+	notEvictableCount  int
+	evictionRecords    []EvictionRecord
 }
 
 func NewPodEvictor(
@@ -62,6 +182,7 @@ func NewPodEvictor(
 	nodes []*v1.Node,
 	evictLocalStoragePods bool,
 	ignorePvcPods bool,
+	opts ...func(pe *PodEvictor),
 ) *PodEvictor {
 	var nodePodCount = make(nodePodEvictedCount)
 	for _, node := range nodes {
@@ -69,24 +190,42 @@ func NewPodEvictor(
 		nodePodCount[node] = 0
 	}
 
-	return &PodEvictor{
+	pe := &PodEvictor{
 		client:                client,
 		policyGroupVersion:    policyGroupVersion,
 		dryRun:                dryRun,
 		maxPodsToEvictPerNode: maxPodsToEvictPerNode,
 		nodepodCount:          nodePodCount,
+		namespaceCount:        make(map[string]int),
+		ownerCount:            make(map[string]int),
+		zoneCount:             make(map[string]int),
+		cooldownCount:         make(map[string]int),
+		workloads:             make(map[string]string),
+		restartedWorkloads:    make(map[string]bool),
 		evictLocalStoragePods: evictLocalStoragePods,
 		ignorePvcPods:         ignorePvcPods,
 	}
+	for _, opt := range opts {
+		opt(pe)
+	}
+	return pe
 }
 
 // NodeEvicted gives a number of pods evicted for node
 func (pe *PodEvictor) NodeEvicted(node *v1.Node) int {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
 	return pe.nodepodCount[node]
 }
 
 // TotalEvicted gives a number of pods evicted through all nodes
 func (pe *PodEvictor) TotalEvicted() int {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	return pe.totalEvicted()
+}
+
+func (pe *PodEvictor) totalEvicted() int {
 	var total int
 	for _, count := range pe.nodepodCount {
 		total += count
@@ -94,45 +233,256 @@ func (pe *PodEvictor) TotalEvicted() int {
 	return total
 }
 
+// TotalLimitReached tells whether no more pods can be evicted in the cycle
+func (pe *PodEvictor) TotalLimitReached() bool {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	return pe.limits.Total > 0 && pe.totalEvicted() >= pe.limits.Total
+}
+
+// podOwner returns the controller of the pod, or its first owner if none of the
+// owners is the controller. It returns nil for pods without owners.
+func podOwner(pod *v1.Pod) *metav1.OwnerReference {
+	if ownerRef := metav1.GetControllerOf(pod); ownerRef != nil {
+		return ownerRef
+	}
+	ownerRefs := podutil.OwnerRef(pod)
+	if len(ownerRefs) == 0 {
+		return nil
+	}
+	return &ownerRefs[0]
+}
+
+// ownerKey identifies the workload owning the pod, it is empty for pods without owners
+func ownerKey(pod *v1.Pod) string {
+	ownerRef := podOwner(pod)
+	if ownerRef == nil {
+		return ""
+	}
+	return pod.Namespace + "/" + ownerRef.Kind + "/" + ownerRef.Name
+}
+
+// reserve counts the eviction of the pod against every limit and budget, or returns the
+// error of the first limit the eviction would exceed. It is called with the lock held.
+func (pe *PodEvictor) reserve(pod *v1.Pod, node *v1.Node, pdbs []*policy.PodDisruptionBudget) error {
+	owner := ownerKey(pod)
+	workload := pe.workloadKey(pod)
+	zone := node.Labels[v1.LabelTopologyZone]
+	switch {
+	case pe.limits.Total > 0 && pe.totalEvicted()+1 > pe.limits.Total:
+		return fmt.Errorf("%w (%v)", ErrTotalLimitReached, pe.limits.Total)
+	case pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode:
+		return fmt.Errorf("%w (%v on node %q)", ErrNodeLimitReached, pe.maxPodsToEvictPerNode, node.Name)
+	case pe.limits.PerZone > 0 && zone != "" && pe.zoneCount[zone]+1 > pe.limits.PerZone:
+		return fmt.Errorf("%w (%v in zone %q)", ErrZoneLimitReached, pe.limits.PerZone, zone)
+	case pe.limits.PerNamespace > 0 && pe.namespaceCount[pod.Namespace]+1 > pe.limits.PerNamespace:
+		return fmt.Errorf("%w (%v in namespace %q)", ErrNamespaceLimitReached, pe.limits.PerNamespace, pod.Namespace)
+	case pe.limits.PerOwner > 0 && owner != "" && pe.ownerCount[owner]+1 > pe.limits.PerOwner:
+		return fmt.Errorf("%w (%v of owner %q)", ErrOwnerLimitReached, pe.limits.PerOwner, owner)
+	case pe.history != nil && workload != "" && (pe.cooldownCount[workload] > 0 || pe.history.EvictedSince(workload, time.Now().Add(-pe.cooldown))):
+		return fmt.Errorf("%w (%v of workload %q)", ErrOwnerCooldown, pe.cooldown, workload)
+	}
+	if pdb := pe.takeDisruptions(pdbs); pdb != nil {
+		return fmt.Errorf("%w (%s/%s)", ErrPodDisruptionBudget, pdb.Namespace, pdb.Name)
+	}
+
+	pe.nodepodCount[node]++
+	pe.namespaceCount[pod.Namespace]++
+	if owner != "" {
+		pe.ownerCount[owner]++
+		pe.cooldownCount[workload]++
+	}
+	if zone != "" {
+		pe.zoneCount[zone]++
+	}
+	return nil
+}
+
+// release takes back the reservation of a failed eviction. It is called with the lock held.
+func (pe *PodEvictor) release(pod *v1.Pod, node *v1.Node, pdbs []*policy.PodDisruptionBudget) {
+	pe.returnDisruptions(pdbs)
+	pe.nodepodCount[node]--
+	pe.namespaceCount[pod.Namespace]--
+	if owner := ownerKey(pod); owner != "" {
+		pe.ownerCount[owner]--
+		pe.cooldownCount[pe.workloadKey(pod)]--
+	}
+	if zone := node.Labels[v1.LabelTopologyZone]; zone != "" {
+		pe.zoneCount[zone]--
+	}
+}
+
+// TotalNotEvictable gives a number of pods the strategies skipped as not evictable.
+// A pod is counted once for every strategy which skipped it.
+func (pe *PodEvictor) TotalNotEvictable() int {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	return pe.notEvictableCount
+}
+
+type strategyNameKey struct{}
+
+// WithStrategyName returns a copy of ctx carrying the name of the strategy
+// the pods are evicted by. The name is used to label the eviction metrics.
+func WithStrategyName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, strategyNameKey{}, name)
+}
+
+func strategyName(ctx context.Context) string {
+	name, _ := ctx.Value(strategyNameKey{}).(string)
+	return name
+}
+
+type deleteOptionsKey struct{}
+
+// WithDeleteOptions returns a copy of ctx carrying the options the pods evicted
+// with the context are deleted with.
+func WithDeleteOptions(ctx context.Context, deleteOptions *metav1.DeleteOptions) context.Context {
+	return context.WithValue(ctx, deleteOptionsKey{}, deleteOptions)
+}
+
+func deleteOptions(ctx context.Context) *metav1.DeleteOptions {
+	if deleteOptions, ok := ctx.Value(deleteOptionsKey{}).(*metav1.DeleteOptions); ok && deleteOptions != nil {
+		return deleteOptions.DeepCopy()
+	}
+	return &metav1.DeleteOptions{}
+}
+
+// uncancelledContext keeps the values of its parent context but is never cancelled
+type uncancelledContext struct {
+	context.Context
+}
+
+func (uncancelledContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (uncancelledContext) Done() <-chan struct{}       { return nil }
+func (uncancelledContext) Err() error                  { return nil }
+
 // EvictPod returns non-nil error only when evicting a pod on a node is not
-// possible (due to maxPodsToEvictPerNode constraint). Success is true when the pod
-// is evicted on the server side.
+// possible due to one of the eviction limits, or the context is cancelled, in
+// which case no new eviction is started. The error tells which limit was reached,
+// see IsPodLimitError and IsStopError. Success is true when the pod is evicted
+// on the server side. An eviction deferred until the replacement of an earlier
+// evicted pod of its owner is ready returns ErrEvictionDeferred, see WithReplacementWait.
 func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, node *v1.Node, reasons ...string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, fmt.Errorf("not evicting pod %q, descheduling is stopping: %w", pod.Name, ctx.Err())
+	}
+
+	snapshot := podutil.SnapshotFrom(ctx)
+	if snapshot != nil && snapshot.IsEvicted(pod) {
+		klog.V(3).InfoS("Pod was already evicted in this cycle", "pod", klog.KObj(pod))
+		return false, nil
+	}
+
+	// the eviction is counted before it is made, so concurrent evictions
+	// can not exceed the limits
+	pdbs := pe.podDisruptionBudgets(pod)
+	if pe.history != nil {
+		pe.resolveWorkload(ctx, pod)
+	}
+	pe.lock.Lock()
+	err := pe.reserve(pod, node, pdbs)
+	pe.lock.Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	// a rollout restart is paced by the workload controller already
+	if pe.replacementTimeout > 0 && !pe.dryRun && disruptionMode(ctx) != DisruptionModeRolloutRestart {
+		if ownerRef := pacedOwnerRef(pod); ownerRef != nil {
+			return pe.evictPaced(ctx, pod, node, pdbs, reasons, ownerRef)
+		}
+	}
+	return pe.evict(ctx, pod, node, pdbs, reasons)
+}
+
+// evict makes an eviction reserved by EvictPod, releasing the reservation if it fails
+func (pe *PodEvictor) evict(ctx context.Context, pod *v1.Pod, node *v1.Node, pdbs []*policy.PodDisruptionBudget, reasons []string) (bool, error) {
 	var reason string
 	if len(reasons) > 0 {
 		reason = " (" + strings.Join(reasons, ", ") + ")"
 	}
-	if pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode {
-		return false, fmt.Errorf("Maximum number %v of evicted pods per %q node reached", pe.maxPodsToEvictPerNode, node.Name)
+
+	if pe.rateLimiter != nil && !pe.dryRun {
+		if err := pe.rateLimiter.Wait(ctx); err != nil {
+			pe.lock.Lock()
+			pe.release(pod, node, pdbs)
+			pe.lock.Unlock()
+			return false, fmt.Errorf("not evicting pod %q, descheduling is stopping: %w", pod.Name, err)
+		}
 	}
 
-	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion, pe.dryRun)
+	if !pe.approved(ctx, pod, node, reasons) {
+		pe.lock.Lock()
+		pe.release(pod, node, pdbs)
+		pe.lock.Unlock()
+		if ctx.Err() != nil {
+			return false, fmt.Errorf("not evicting pod %q, descheduling is stopping: %w", pod.Name, ctx.Err())
+		}
+		return false, nil
+	}
+
+	strategy := strategyName(ctx)
+	mode := disruptionMode(ctx)
+	// an eviction which was started is not cut off when descheduling stops meanwhile
+	err := pe.disrupt(uncancelledContext{ctx}, pod)
+	if goerrors.Is(err, errWorkloadRestarted) {
+		// the workload counts as one disruption, made with its first pod
+		klog.V(2).InfoS("Pod is replaced by the rollout restart of its workload", "pod", klog.KObj(pod))
+		pe.lock.Lock()
+		pe.release(pod, node, pdbs)
+		pe.lock.Unlock()
+		return false, nil
+	}
 	if err != nil {
 		// err is used only for logging purposes
-		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", reason)
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "mode", mode, "reason", reason)
+		failureReason := string(apierrors.ReasonForError(err))
+		if failureReason == "" {
+			failureReason = "Unknown"
+		}
+		metrics.PodEvictionsFailed.WithLabelValues(strategy, pod.Namespace, node.Name, failureReason).Inc()
+		pe.lock.Lock()
+		pe.release(pod, node, pdbs)
+		pe.lock.Unlock()
 		return false, nil
 	}
 
-	pe.nodepodCount[node]++
+	if snapshot := podutil.SnapshotFrom(ctx); snapshot != nil {
+		snapshot.MarkEvicted(pod)
+	}
+	pe.lock.Lock()
+	if pe.history != nil && !pe.dryRun {
+		pe.history.Record(pe.workloadKey(pod), time.Now())
+	}
+	pe.evictionRecords = append(pe.evictionRecords, newEvictionRecord(ctx, pod, node, reasons, pe.dryRun))
+	pe.lock.Unlock()
 	if pe.dryRun {
-		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", reason)
+		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "mode", mode, "reason", reason)
 	} else {
-		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", reason)
-		eventBroadcaster := record.NewBroadcaster()
-		eventBroadcaster.StartStructuredLogging(3)
-		eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: pe.client.CoreV1().Events(pod.Namespace)})
-		r := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
-		r.Event(pod, v1.EventTypeNormal, "Descheduled", fmt.Sprintf("pod evicted by sigs.k8s.io/descheduler%s", reason))
+		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "mode", mode, "reason", reason)
+		metrics.PodsEvicted.WithLabelValues(strategy, pod.Namespace, node.Name, strings.Join(reasons, ", ")).Inc()
 	}
+	pe.recordEvents(uncancelledContext{ctx}, pod, node, reason)
 	return true, nil
 }
 
-func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, dryRun bool) error {
+// EvictionFunc sends the eviction of a pod to the API server
+type EvictionFunc func(ctx context.Context, client clientset.Interface, eviction *policy.Eviction) error
+
+// WithEvictionFunc sends the evictions with the given function instead of the client of the
+// policy group version, e.g. through a fake clientset, which has no REST client for policy/v1.
+func WithEvictionFunc(evict EvictionFunc) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.evictionFunc = evict
+	}
+}
+
+// evictPod evicts the pod with the eviction func, or with the client of the policy group version if it is nil
+func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, deleteOptions *metav1.DeleteOptions, dryRun bool, evict EvictionFunc) error {
 	if dryRun {
 		return nil
 	}
-	deleteOptions := &metav1.DeleteOptions{}
-	// GracePeriodSeconds ?
 	eviction := &policy.Eviction{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: policyGroupVersion,
@@ -144,17 +494,51 @@ func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, poli
 		},
 		DeleteOptions: deleteOptions,
 	}
-	err := client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	if evict == nil {
+		evict = evictPodV1beta1
+		if policyGroupVersion == eutils.PolicyV1GroupVersion {
+			evict = evictPodV1
+		}
+	}
+	err := evict(ctx, client, eviction)
 
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
-		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+		return fmt.Errorf("pod not found when evicting %q: %w", pod.Name, err)
 	}
 	return err
 }
 
+func evictPodV1beta1(ctx context.Context, client clientset.Interface, eviction *policy.Eviction) error {
+	return client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+}
+
+// evictPodV1 posts the eviction to the pods/eviction subresource as a policy/v1 Eviction.
+// client-go has no typed policy/v1 client at this version, the v1 Eviction has the same
+// fields as the v1beta1 one though, so it is sent as JSON with the v1 apiVersion.
+func evictPodV1(ctx context.Context, client clientset.Interface, eviction *policy.Eviction) error {
+	restClient := client.CoreV1().RESTClient()
+	if rc, ok := restClient.(*rest.RESTClient); ok && rc == nil {
+		return fmt.Errorf("unable to evict pod %q: the client has no REST client to send a policy/v1 eviction", eviction.Name)
+	}
+	body, err := json.Marshal(eviction)
+	if err != nil {
+		return fmt.Errorf("unable to encode eviction of pod %q: %v", eviction.Name, err)
+	}
+	return restClient.Post().
+		AbsPath("/api/v1").
+		Namespace(eviction.Namespace).
+		Resource("pods").
+		Name(eviction.Name).
+		SubResource("eviction").
+		SetHeader("Content-Type", runtime.ContentTypeJSON).
+		Body(body).
+		Do(ctx).
+		Error()
+}
+
 type Options struct {
 	priority *int32
 }
@@ -172,6 +556,9 @@ type constraint func(pod *v1.Pod) error
 
 type evictable struct {
 	constraints []constraint
+	podEvictor  *PodEvictor
+	// notEvictable keeps the pods already counted as not evictable, protected by the lock of the podEvictor
+	notEvictable map[types.NamespacedName]struct{}
 }
 
 // Evictable provides an implementation of IsEvictable(IsEvictable(pod *v1.Pod) bool).
@@ -183,7 +570,10 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 		opt(options)
 	}
 
-	ev := &evictable{}
+	ev := &evictable{
+		podEvictor:   pe,
+		notEvictable: make(map[types.NamespacedName]struct{}),
+	}
 	if !pe.evictLocalStoragePods {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
 			if IsPodWithLocalStorage(pod) {
@@ -239,6 +629,14 @@ func (ev *evictable) IsEvictable(pod *v1.Pod) bool {
 
 	if len(checkErrs) > 0 && !HaveEvictAnnotation(pod) {
 		klog.V(4).InfoS("Pod lacks an eviction annotation and fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
+		// strategies may check the same pod several times, count it only once
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		ev.podEvictor.lock.Lock()
+		if _, ok := ev.notEvictable[key]; !ok {
+			ev.notEvictable[key] = struct{}{}
+			ev.podEvictor.notEvictableCount++
+		}
+		ev.podEvictor.lock.Unlock()
 		return false
 	}
 	return true
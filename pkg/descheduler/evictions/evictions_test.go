@@ -18,14 +18,32 @@ package evictions
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/component-base/metrics/testutil"
+	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/metrics"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
 )
@@ -62,13 +80,245 @@ func TestEvictPod(t *testing.T) {
 		fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
 			return true, &v1.PodList{Items: test.pods}, nil
 		})
-		got := evictPod(ctx, fakeClient, test.pod, "v1", false)
+		got := evictPod(ctx, fakeClient, test.pod, "v1", &metav1.DeleteOptions{}, false, nil)
 		if got != test.want {
 			t.Errorf("Test error for Desc: %s. Expected %v pod eviction to be %v, got %v", test.description, test.pod.Name, test.want, got)
 		}
 	}
 }
 
+func TestEvictPodPolicyGroupVersion(t *testing.T) {
+	ctx := context.Background()
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+
+	t.Run("policy/v1beta1", func(t *testing.T) {
+		var evictedAPIVersion string
+		fakeClient := &fake.Clientset{}
+		fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() == "eviction" {
+				evictedAPIVersion = action.(core.CreateAction).GetObject().(*policyv1beta1.Eviction).APIVersion
+			}
+			return true, nil, nil
+		})
+		if err := evictPod(ctx, fakeClient, pod1, "policy/v1beta1", &metav1.DeleteOptions{}, false, nil); err != nil {
+			t.Fatalf("Unable to evict pod: %v", err)
+		}
+		if evictedAPIVersion != "policy/v1beta1" {
+			t.Errorf("Expected a policy/v1beta1 eviction, got %q", evictedAPIVersion)
+		}
+	})
+
+	t.Run("eviction func", func(t *testing.T) {
+		var evicted []string
+		node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", false, 0, []*v1.Node{node1}, false, false,
+			WithEvictionFunc(func(ctx context.Context, client clientset.Interface, eviction *policyv1beta1.Eviction) error {
+				evicted = append(evicted, eviction.APIVersion+" "+eviction.Name)
+				return nil
+			}))
+		if success, err := podEvictor.EvictPod(ctx, pod1, node1); !success || err != nil {
+			t.Fatalf("Expected pod %v to be evicted, got %v, %v", pod1.Name, success, err)
+		}
+		if expected := []string{"policy/v1 p1"}; !reflect.DeepEqual(evicted, expected) {
+			t.Errorf("Expected evictions %v, got %v", expected, evicted)
+		}
+	})
+
+	t.Run("policy/v1 through the REST client", func(t *testing.T) {
+		var lock sync.Mutex
+		var requests []string
+		status := http.StatusCreated
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			eviction := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&eviction); err != nil {
+				t.Errorf("Unable to decode eviction: %v", err)
+			}
+			lock.Lock()
+			requests = append(requests, fmt.Sprintf("%v %v %v", r.Method, r.URL.Path, eviction["apiVersion"]))
+			lock.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusSuccess,
+				Code:     int32(status),
+			})
+		}))
+		defer server.Close()
+		client, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+		if err != nil {
+			t.Fatalf("Unable to create client: %v", err)
+		}
+
+		if err := evictPod(ctx, client, pod1, "policy/v1", &metav1.DeleteOptions{}, false, nil); err != nil {
+			t.Fatalf("Unable to evict pod: %v", err)
+		}
+		expected := []string{"POST /api/v1/namespaces/default/pods/p1/eviction policy/v1"}
+		if !reflect.DeepEqual(requests, expected) {
+			t.Errorf("Expected requests %v, got %v", expected, requests)
+		}
+
+		status = http.StatusTooManyRequests
+		if err := evictPod(ctx, client, pod1, "policy/v1", &metav1.DeleteOptions{}, false, nil); !apierrors.IsTooManyRequests(err) {
+			t.Errorf("Expected a TooManyRequests error, got %v", err)
+		}
+	})
+}
+
+func TestSupportEviction(t *testing.T) {
+	evictionResources := func(version string) *metav1.APIResourceList {
+		return &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: eutils.EvictionSubresource, Group: "policy", Version: version, Kind: eutils.EvictionKind}},
+		}
+	}
+	tests := []struct {
+		description string
+		resources   []*metav1.APIResourceList
+		expected    string
+	}{
+		{
+			description: "policy/v1beta1 only",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1beta1"}, evictionResources("v1beta1")},
+			expected:    "policy/v1beta1",
+		},
+		{
+			description: "eviction subresource advertises policy/v1",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1beta1"}, {GroupVersion: "policy/v1"}, evictionResources("v1")},
+			expected:    "policy/v1",
+		},
+		{
+			description: "policy/v1 served for PodDisruptionBudgets only",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1beta1"}, {GroupVersion: "policy/v1"}, evictionResources("v1beta1")},
+			expected:    "policy/v1beta1",
+		},
+		{
+			description: "eviction subresource without a version",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1"}, evictionResources("")},
+			expected:    "policy/v1beta1",
+		},
+		{
+			description: "no policy group",
+			resources:   []*metav1.APIResourceList{evictionResources("v1")},
+			expected:    "",
+		},
+		{
+			description: "no eviction subresource",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1"}, {GroupVersion: "v1"}},
+			expected:    "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			fakeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = test.resources
+			policyGroupVersion, err := eutils.SupportEviction(fakeClient)
+			if err != nil {
+				t.Fatalf("Unable to discover eviction support: %v", err)
+			}
+			if policyGroupVersion != test.expected {
+				t.Errorf("Expected eviction group version %q, got %q", test.expected, policyGroupVersion)
+			}
+		})
+	}
+}
+
+func TestSupportPodDisruptionBudgets(t *testing.T) {
+	tests := []struct {
+		description string
+		resources   []*metav1.APIResourceList
+		expected    bool
+	}{
+		{
+			description: "policy/v1beta1 PodDisruptionBudgets",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget"}}}},
+			expected:    true,
+		},
+		{
+			description: "policy/v1beta1 without PodDisruptionBudgets",
+			resources:   []*metav1.APIResourceList{{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{{Name: "podsecuritypolicies", Kind: "PodSecurityPolicy"}}}},
+			expected:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			fakeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = test.resources
+			served, err := eutils.SupportPodDisruptionBudgets(fakeClient)
+			if err != nil {
+				t.Fatalf("Unable to discover PodDisruptionBudgets: %v", err)
+			}
+			if served != test.expected {
+				t.Errorf("Expected PodDisruptionBudgets served %v, got %v", test.expected, served)
+			}
+		})
+	}
+}
+
+func TestEvictPodMetrics(t *testing.T) {
+	metrics.Register()
+	ctx := WithStrategyName(context.Background(), "TestEvictPodMetrics")
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	pod2 := test.BuildTestPod("p2", 400, 0, "node1", nil)
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" && action.(core.CreateAction).GetObject().(metav1.Object).GetName() == pod2.Name {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+		}
+		return true, nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 0, []*v1.Node{node1}, false, false)
+
+	if success, err := podEvictor.EvictPod(ctx, pod1, node1, "TestReason"); !success || err != nil {
+		t.Fatalf("Expected pod %v to be evicted, got %v, %v", pod1.Name, success, err)
+	}
+	if success, err := podEvictor.EvictPod(ctx, pod2, node1, "TestReason"); success || err != nil {
+		t.Fatalf("Expected eviction of pod %v to fail without an error, got %v, %v", pod2.Name, success, err)
+	}
+
+	evicted, err := testutil.GetCounterMetricValue(metrics.PodsEvicted.WithLabelValues("TestEvictPodMetrics", "default", "node1", "TestReason"))
+	if err != nil {
+		t.Fatalf("Unable to get the evicted pods metric: %v", err)
+	}
+	if evicted != 1 {
+		t.Errorf("Expected 1 evicted pod, got %v", evicted)
+	}
+	failed, err := testutil.GetCounterMetricValue(metrics.PodEvictionsFailed.WithLabelValues("TestEvictPodMetrics", "default", "node1", "TooManyRequests"))
+	if err != nil {
+		t.Fatalf("Unable to get the failed evictions metric: %v", err)
+	}
+	if failed != 1 {
+		t.Errorf("Expected 1 failed eviction, got %v", failed)
+	}
+}
+
+func TestTotalNotEvictable(t *testing.T) {
+	podEvictor := &PodEvictor{}
+	evictablePod := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	evictablePod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+	notEvictablePod := test.BuildTestPod("p2", 400, 0, "node1", nil)
+
+	evictable := podEvictor.Evictable()
+	for i := 0; i < 3; i++ {
+		evictable.IsEvictable(evictablePod)
+		evictable.IsEvictable(notEvictablePod)
+	}
+	if podEvictor.TotalNotEvictable() != 1 {
+		t.Errorf("Expected a pod checked several times to be counted once, got %v", podEvictor.TotalNotEvictable())
+	}
+
+	// every strategy gets its own evictable and counts the pod again
+	podEvictor.Evictable().IsEvictable(notEvictablePod)
+	if podEvictor.TotalNotEvictable() != 2 {
+		t.Errorf("Expected 2 pods not evictable, got %v", podEvictor.TotalNotEvictable())
+	}
+}
+
 func TestIsEvictable(t *testing.T) {
 	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
 	lowPriority := int32(800)
@@ -302,3 +552,302 @@ func TestPodTypes(t *testing.T) {
 	}
 
 }
+
+func TestEvictPodContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		t.Errorf("Unexpected eviction of pod %v", pod1.Name)
+		return true, nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 0, []*v1.Node{node1}, false, false)
+
+	success, err := podEvictor.EvictPod(ctx, pod1, node1)
+	if success || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected eviction to be refused with %v, got %v, %v", context.Canceled, success, err)
+	}
+	if podEvictor.TotalEvicted() != 0 {
+		t.Errorf("Expected no pods to be evicted, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodMarksSnapshot(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	pod2 := test.BuildTestPod("p2", 400, 0, "node1", nil)
+	snapshot := podutil.NewSnapshot([]*v1.Pod{pod1, pod2})
+	ctx := podutil.WithSnapshot(context.Background(), snapshot)
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", true, 0, []*v1.Node{node1}, false, false)
+	if success, err := podEvictor.EvictPod(ctx, pod1, node1); !success || err != nil {
+		t.Fatalf("Expected pod %v to be evicted, got %v, %v", pod1.Name, success, err)
+	}
+	if !snapshot.IsEvicted(pod1) || snapshot.IsEvicted(pod2) {
+		t.Errorf("Expected only pod %v to be marked evicted", pod1.Name)
+	}
+	if pods := snapshot.PodsOnNode(node1.Name); len(pods) != 1 || pods[0].Name != pod2.Name {
+		t.Errorf("Expected only pod %v to be left on the node, got %v", pod2.Name, pods)
+	}
+
+	if success, err := podEvictor.EvictPod(ctx, pod1, node1); success || err != nil {
+		t.Errorf("Expected pod %v not to be evicted twice, got %v, %v", pod1.Name, success, err)
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 evicted pod, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodConcurrentLimit(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, 5, []*v1.Node{node1, node2}, false, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for _, node := range []*v1.Node{node1, node2} {
+			wg.Add(1)
+			go func(pod *v1.Pod, node *v1.Node) {
+				defer wg.Done()
+				podEvictor.EvictPod(ctx, pod, node)
+			}(test.BuildTestPod(fmt.Sprintf("%s-p%d", node.Name, i), 100, 0, node.Name, nil), node)
+		}
+	}
+	wg.Wait()
+
+	for _, node := range []*v1.Node{node1, node2} {
+		if podEvictor.NodeEvicted(node) != 5 {
+			t.Errorf("Expected 5 pods to be evicted on node %v, got %v", node.Name, podEvictor.NodeEvicted(node))
+		}
+	}
+	if podEvictor.TotalEvicted() != 10 {
+		t.Errorf("Expected 10 evicted pods, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodLimits(t *testing.T) {
+	ctx := context.Background()
+	zoneA := func(node *v1.Node) { node.Labels = map[string]string{v1.LabelTopologyZone: "a"} }
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, zoneA)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, zoneA)
+	node3 := test.BuildTestNode("node3", 1000, 2000, 9, nil)
+	inNamespace := func(namespace string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs-" + pod.Name}}
+		}
+	}
+	ownedBy := func(owner string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}}
+		}
+	}
+
+	type eviction struct {
+		pod      *v1.Pod
+		node     *v1.Node
+		err      error
+		podLimit bool
+		stop     bool
+	}
+	tests := []struct {
+		description string
+		limits      EvictionLimits
+		maxPerNode  int
+		evictions   []eviction
+	}{
+		{
+			description: "limit per node",
+			maxPerNode:  1,
+			evictions: []eviction{
+				{pod: test.BuildTestPod("p1", 100, 0, "node1", nil), node: node1},
+				{pod: test.BuildTestPod("p2", 100, 0, "node1", nil), node: node1, err: ErrNodeLimitReached},
+				{pod: test.BuildTestPod("p3", 100, 0, "node2", nil), node: node2},
+			},
+		},
+		{
+			description: "limit per namespace",
+			limits:      EvictionLimits{PerNamespace: 1},
+			evictions: []eviction{
+				{pod: test.BuildTestPod("p1", 100, 0, "node1", inNamespace("a")), node: node1},
+				{pod: test.BuildTestPod("p2", 100, 0, "node1", inNamespace("a")), node: node1, err: ErrNamespaceLimitReached, podLimit: true},
+				{pod: test.BuildTestPod("p3", 100, 0, "node1", inNamespace("b")), node: node1},
+			},
+		},
+		{
+			description: "limit per owner",
+			limits:      EvictionLimits{PerOwner: 1},
+			evictions: []eviction{
+				{pod: test.BuildTestPod("p1", 100, 0, "node1", ownedBy("rs-1")), node: node1},
+				{pod: test.BuildTestPod("p2", 100, 0, "node2", ownedBy("rs-1")), node: node2, err: ErrOwnerLimitReached, podLimit: true},
+				{pod: test.BuildTestPod("p3", 100, 0, "node2", ownedBy("rs-2")), node: node2},
+				{pod: test.BuildTestPod("p4", 100, 0, "node2", nil), node: node2},
+			},
+		},
+		{
+			description: "limit per zone",
+			limits:      EvictionLimits{PerZone: 1},
+			evictions: []eviction{
+				{pod: test.BuildTestPod("p1", 100, 0, "node1", nil), node: node1},
+				{pod: test.BuildTestPod("p2", 100, 0, "node2", nil), node: node2, err: ErrZoneLimitReached},
+				{pod: test.BuildTestPod("p3", 100, 0, "node3", nil), node: node3},
+				{pod: test.BuildTestPod("p4", 100, 0, "node3", nil), node: node3},
+			},
+		},
+		{
+			description: "limit in total",
+			limits:      EvictionLimits{Total: 2},
+			evictions: []eviction{
+				{pod: test.BuildTestPod("p1", 100, 0, "node1", nil), node: node1},
+				{pod: test.BuildTestPod("p2", 100, 0, "node2", nil), node: node2},
+				{pod: test.BuildTestPod("p3", 100, 0, "node3", nil), node: node3, err: ErrTotalLimitReached, stop: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, tc.maxPerNode, []*v1.Node{node1, node2, node3}, false, false, WithEvictionLimits(tc.limits))
+		for _, e := range tc.evictions {
+			_, err := podEvictor.EvictPod(ctx, e.pod, e.node)
+			if !errors.Is(err, e.err) || (err == nil) != (e.err == nil) {
+				t.Errorf("%v: expected error %v evicting pod %v, got %v", tc.description, e.err, e.pod.Name, err)
+			}
+			if IsPodLimitError(err) != e.podLimit {
+				t.Errorf("%v: expected IsPodLimitError to be %v for pod %v, got %v", tc.description, e.podLimit, e.pod.Name, !e.podLimit)
+			}
+			if IsStopError(err) != e.stop {
+				t.Errorf("%v: expected IsStopError to be %v for pod %v, got %v", tc.description, e.stop, e.pod.Name, !e.stop)
+			}
+		}
+		if podEvictor.TotalLimitReached() != (tc.limits.Total > 0) {
+			t.Errorf("%v: expected TotalLimitReached to be %v", tc.description, tc.limits.Total > 0)
+		}
+	}
+}
+
+func TestEvictPodReleasesLimitsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	pod2 := test.BuildTestPod("p2", 400, 0, "node1", nil)
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			return true, nil, apierrors.NewTooManyRequests("cannot evict", 1)
+		}
+		return false, nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 1, []*v1.Node{node1}, false, false, WithEvictionLimits(EvictionLimits{PerNamespace: 1, Total: 1}))
+	for _, pod := range []*v1.Pod{pod1, pod2} {
+		if _, err := podEvictor.EvictPod(ctx, pod, node1); err != nil {
+			t.Errorf("Expected a failed eviction not to count against the limits, got %v evicting pod %v", err, pod.Name)
+		}
+	}
+	if podEvictor.TotalEvicted() != 0 {
+		t.Errorf("Expected no evicted pods, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodRateLimited(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	var pods []*v1.Pod
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, node1.Name, nil))
+	}
+
+	t.Run("evictions wait for the rate limiter", func(t *testing.T) {
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithRateLimiter(flowcontrol.NewTokenBucketRateLimiter(20, 2)))
+		start := time.Now()
+		for _, pod := range pods {
+			if _, err := podEvictor.EvictPod(context.Background(), pod, node1); err != nil {
+				t.Fatalf("Unable to evict pod %v: %v", pod.Name, err)
+			}
+		}
+		// the burst allows 2 evictions at once, the next 2 wait 50ms each
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Errorf("Expected the evictions to be spread over at least 100ms, took %v", elapsed)
+		}
+		if podEvictor.TotalEvicted() != len(pods) {
+			t.Errorf("Expected %v evicted pods, got %v", len(pods), podEvictor.TotalEvicted())
+		}
+	})
+
+	t.Run("waiting stops with the context", func(t *testing.T) {
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithRateLimiter(flowcontrol.NewTokenBucketRateLimiter(0.001, 1)))
+		ctx, cancel := context.WithCancel(context.Background())
+		if _, err := podEvictor.EvictPod(ctx, pods[0], node1); err != nil {
+			t.Fatalf("Unable to evict pod: %v", err)
+		}
+		time.AfterFunc(50*time.Millisecond, cancel)
+		success, err := podEvictor.EvictPod(ctx, pods[1], node1)
+		if success || !IsStopError(err) {
+			t.Errorf("Expected the eviction to stop with the context, got success %v and error %v", success, err)
+		}
+		if podEvictor.TotalEvicted() != 1 {
+			t.Errorf("Expected 1 evicted pod, got %v", podEvictor.TotalEvicted())
+		}
+	})
+
+	t.Run("dry run is not rate limited", func(t *testing.T) {
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", true, 0, []*v1.Node{node1}, false, false, WithRateLimiter(flowcontrol.NewTokenBucketRateLimiter(0.001, 1)))
+		for _, pod := range pods {
+			if _, err := podEvictor.EvictPod(context.Background(), pod, node1); err != nil {
+				t.Fatalf("Unable to evict pod %v: %v", pod.Name, err)
+			}
+		}
+		if podEvictor.TotalEvicted() != len(pods) {
+			t.Errorf("Expected %v evicted pods, got %v", len(pods), podEvictor.TotalEvicted())
+		}
+	})
+}
+
+func TestEvictPodDeleteOptions(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	gracePeriodSeconds := int64(5)
+	background := metav1.DeletePropagationBackground
+
+	tests := []struct {
+		description   string
+		deleteOptions *metav1.DeleteOptions
+		want          *metav1.DeleteOptions
+	}{
+		{
+			description: "no delete options",
+			want:        &metav1.DeleteOptions{},
+		},
+		{
+			description:   "delete options of the context",
+			deleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &background},
+			want:          &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &background},
+		},
+	}
+
+	for _, tc := range tests {
+		var got *metav1.DeleteOptions
+		fakeClient := &fake.Clientset{}
+		fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() == "eviction" {
+				got = action.(core.CreateAction).GetObject().(*policyv1beta1.Eviction).DeleteOptions
+			}
+			return true, nil, nil
+		})
+
+		ctx := context.Background()
+		if tc.deleteOptions != nil {
+			ctx = WithDeleteOptions(ctx, tc.deleteOptions)
+		}
+		podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 0, []*v1.Node{node1}, false, false)
+		if _, err := podEvictor.EvictPod(ctx, pod1, node1); err != nil {
+			t.Fatalf("%v: unable to evict pod: %v", tc.description, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: expected delete options %v, got %v", tc.description, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// EvictionHistory keeps the time pods of each workload were last evicted. It is stored
+// in a ConfigMap with a key per workload, so it survives restarts of the descheduler.
+type EvictionHistory struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+
+	lock sync.Mutex
+	// configMap is the last version of the ConfigMap read or written, nil if it does not exist
+	configMap *v1.ConfigMap
+	evictions map[string]time.Time
+	changed   bool
+}
+
+// LoadEvictionHistory reads the eviction history from the ConfigMap. The history is
+// empty if the ConfigMap does not exist yet, it is created by the first Save.
+func LoadEvictionHistory(ctx context.Context, client clientset.Interface, namespace, name string) (*EvictionHistory, error) {
+	h := &EvictionHistory{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		evictions: make(map[string]time.Time),
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get eviction history %s/%s: %v", namespace, name, err)
+	}
+
+	h.configMap = configMap
+	for key, value := range configMap.Data {
+		evicted, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			klog.ErrorS(err, "Ignoring invalid entry of the eviction history", "configMap", klog.KObj(configMap), "key", key)
+			continue
+		}
+		h.evictions[key] = evicted
+	}
+	return h, nil
+}
+
+// historyKey is the key of the workload, given as namespace/kind/name, in the ConfigMap.
+// Namespaces and kinds do not contain dots, so the key is unique even if the name does.
+func historyKey(workload string) string {
+	return strings.Replace(workload, "/", ".", 2)
+}
+
+// EvictedSince tells whether a pod of the workload, given as namespace/kind/name, was
+// evicted after the given time
+func (h *EvictionHistory) EvictedSince(workload string, since time.Time) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	evicted, ok := h.evictions[historyKey(workload)]
+	return ok && evicted.After(since)
+}
+
+// Record records the eviction of a pod of the workload, given as namespace/kind/name,
+// at the given time
+func (h *EvictionHistory) Record(workload string, evicted time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.evictions[historyKey(workload)] = evicted
+	h.changed = true
+}
+
+// resolveWorkload looks up the Deployment owning the ReplicaSet of the pod, so the pods of
+// every ReplicaSet of a Deployment, e.g. after a rollout, share the cooldown of the Deployment.
+// The ReplicaSet is looked up once per cycle, before the lock is taken, see workloadKey.
+func (pe *PodEvictor) resolveWorkload(ctx context.Context, pod *v1.Pod) {
+	ownerRef := podOwner(pod)
+	if ownerRef == nil || ownerRef.Kind != "ReplicaSet" {
+		return
+	}
+	owner := ownerKey(pod)
+	pe.lock.Lock()
+	_, ok := pe.workloads[owner]
+	pe.lock.Unlock()
+	if ok {
+		return
+	}
+
+	workload := owner
+	rs, err := pe.client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ownerRef.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.V(3).InfoS("Unable to get ReplicaSet of pod, keeping the eviction cooldown of the ReplicaSet", "pod", klog.KObj(pod), "replicaSet", ownerRef.Name, "err", err)
+	} else if rsOwnerRef := metav1.GetControllerOf(rs); rsOwnerRef != nil && rsOwnerRef.Kind == "Deployment" {
+		workload = pod.Namespace + "/" + rsOwnerRef.Kind + "/" + rsOwnerRef.Name
+	}
+	pe.lock.Lock()
+	pe.workloads[owner] = workload
+	pe.lock.Unlock()
+}
+
+// workloadKey identifies the top-level workload of the pod, as resolved by resolveWorkload,
+// it is empty for pods without owners. It is called with the lock held.
+func (pe *PodEvictor) workloadKey(pod *v1.Pod) string {
+	owner := ownerKey(pod)
+	if workload, ok := pe.workloads[owner]; ok {
+		return workload
+	}
+	return owner
+}
+
+// Save writes the history to the ConfigMap, leaving out the evictions made before
+// the given time. Nothing is written if no eviction was recorded since the last Save.
+func (h *EvictionHistory) Save(ctx context.Context, before time.Time) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if !h.changed {
+		return nil
+	}
+
+	data := make(map[string]string)
+	for key, evicted := range h.evictions {
+		if evicted.Before(before) {
+			delete(h.evictions, key)
+			continue
+		}
+		data[key] = evicted.UTC().Format(time.RFC3339)
+	}
+
+	var configMap *v1.ConfigMap
+	var err error
+	if h.configMap == nil {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: h.namespace, Name: h.name},
+			Data:       data,
+		}
+		configMap, err = h.client.CoreV1().ConfigMaps(h.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		configMap = h.configMap.DeepCopy()
+		configMap.Data = data
+		configMap, err = h.client.CoreV1().ConfigMaps(h.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to save eviction history %s/%s: %v", h.namespace, h.name, err)
+	}
+	h.configMap = configMap
+	h.changed = false
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEvictionHistory(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+
+	history, err := LoadEvictionHistory(ctx, client, "kube-system", "history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	history.Record("default/Deployment/web", now.Add(-time.Minute))
+	history.Record("default/ReplicaSet/rs-2", now.Add(-time.Hour))
+	history.Record("default/StatefulSet/db", now.Add(-3*time.Hour))
+	if err := history.Save(ctx, now.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("Unable to save the eviction history: %v", err)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "history", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get the eviction history ConfigMap: %v", err)
+	}
+	if len(configMap.Data) != 2 {
+		t.Errorf("Expected the evictions before the cooldown to be left out, got %v", configMap.Data)
+	}
+	if _, ok := configMap.Data["default.Deployment.web"]; !ok {
+		t.Errorf("Expected the eviction of the Deployment to be saved by namespace, kind and name, got %v", configMap.Data)
+	}
+
+	// a new run of the descheduler reads the history back
+	history, err = LoadEvictionHistory(ctx, client, "kube-system", "history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	if !history.EvictedSince("default/Deployment/web", now.Add(-10*time.Minute)) {
+		t.Errorf("Expected a pod of Deployment web to be evicted within the last 10 minutes")
+	}
+	if history.EvictedSince("default/ReplicaSet/rs-2", now.Add(-10*time.Minute)) {
+		t.Errorf("Expected no pod of ReplicaSet rs-2 to be evicted within the last 10 minutes")
+	}
+
+	history.Record("default/ReplicaSet/rs-2", now)
+	if err := history.Save(ctx, now.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("Unable to save the eviction history: %v", err)
+	}
+	history, err = LoadEvictionHistory(ctx, client, "kube-system", "history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	if !history.EvictedSince("default/ReplicaSet/rs-2", now.Add(-10*time.Minute)) {
+		t.Errorf("Expected the updated history to be saved")
+	}
+}
+
+func TestEvictPodCooldown(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	ownedBy := func(owner string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner}}
+		}
+	}
+	p1 := test.BuildTestPod("p1", 100, 0, "node1", ownedBy("rs-1"))
+	p2 := test.BuildTestPod("p2", 100, 0, "node1", ownedBy("rs-1"))
+	p3 := test.BuildTestPod("p3", 100, 0, "node1", ownedBy("rs-2"))
+	p4 := test.BuildTestPod("p4", 100, 0, "node1", ownedBy("rs-3"))
+	client := fake.NewSimpleClientset(p1, p2, p3, p4)
+
+	history, err := LoadEvictionHistory(ctx, client, "kube-system", "history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	// rs-2 was evicted in an earlier run within the cooldown, rs-3 before it
+	history.Record("default/ReplicaSet/rs-2", time.Now().Add(-time.Minute))
+	history.Record("default/ReplicaSet/rs-3", time.Now().Add(-time.Hour))
+
+	podEvictor := NewPodEvictor(client, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithEvictionHistory(history, 10*time.Minute))
+	for _, tc := range []struct {
+		pod *v1.Pod
+		err error
+	}{
+		{pod: p1},
+		// evicted earlier in the cycle
+		{pod: p2, err: ErrOwnerCooldown},
+		{pod: p3, err: ErrOwnerCooldown},
+		{pod: p4},
+	} {
+		_, err := podEvictor.EvictPod(ctx, tc.pod, node1)
+		if !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+			t.Errorf("Expected error %v evicting pod %v, got %v", tc.err, tc.pod.Name, err)
+		}
+		if err != nil && !IsPodLimitError(err) {
+			t.Errorf("Expected the cooldown to only skip pod %v", tc.pod.Name)
+		}
+	}
+	if !history.EvictedSince("default/ReplicaSet/rs-1", time.Now().Add(-time.Minute)) || !history.EvictedSince("default/ReplicaSet/rs-3", time.Now().Add(-time.Minute)) {
+		t.Errorf("Expected the evictions to be recorded in the history")
+	}
+}
+
+func TestEvictPodCooldownOfDeployment(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	isController := true
+	replicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment, Controller: &isController}},
+		}}
+	}
+	ownedBy := func(owner string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &isController}}
+		}
+	}
+	web2a := test.BuildTestPod("web-2-a", 100, 0, "node1", ownedBy("web-2"))
+	api1a := test.BuildTestPod("api-1-a", 100, 0, "node1", ownedBy("api-1"))
+	api2a := test.BuildTestPod("api-2-a", 100, 0, "node1", ownedBy("api-2"))
+	// the rollouts of Deployment web and api created a new ReplicaSet each
+	client := fake.NewSimpleClientset(replicaSet("web-1", "web"), replicaSet("web-2", "web"), replicaSet("api-1", "api"), replicaSet("api-2", "api"), web2a, api1a, api2a)
+	history, err := LoadEvictionHistory(ctx, client, "kube-system", "history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	// a pod of the old ReplicaSet of web was evicted in an earlier run within the cooldown
+	history.Record("default/Deployment/web", time.Now().Add(-time.Minute))
+
+	podEvictor := NewPodEvictor(client, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithEvictionHistory(history, 10*time.Minute))
+	for _, tc := range []struct {
+		pod *v1.Pod
+		err error
+	}{
+		{pod: web2a, err: ErrOwnerCooldown},
+		{pod: api1a},
+		// the new ReplicaSet of api shares the cooldown of the Deployment
+		{pod: api2a, err: ErrOwnerCooldown},
+	} {
+		_, err := podEvictor.EvictPod(ctx, tc.pod, node1)
+		if !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+			t.Errorf("Expected error %v evicting pod %v, got %v", tc.err, tc.pod.Name, err)
+		}
+	}
+	if !history.EvictedSince("default/Deployment/api", time.Now().Add(-time.Minute)) {
+		t.Errorf("Expected the eviction to be recorded for the Deployment")
+	}
+}
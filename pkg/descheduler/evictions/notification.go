@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// notificationTimeout is the timeout of posting the evictions of a cycle to a WebhookSink
+var notificationTimeout = 10 * time.Second
+
+// EvictionRecords gives the evictions made in the cycle, including the ones in dry run mode,
+// in the order they were made
+func (pe *PodEvictor) EvictionRecords() []EvictionRecord {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	return pe.evictionRecords
+}
+
+// NotificationSink passes the evictions of the descheduler on to downstream systems.
+// Notify is called at the end of every descheduling cycle with the evictions made in it.
+type NotificationSink interface {
+	Notify(ctx context.Context, records []EvictionRecord) error
+}
+
+// AuditFileSink appends every eviction to a file as a line of JSON
+type AuditFileSink struct {
+	file string
+	// lock serializes the writes of cycles of strategies with their own schedule
+	lock sync.Mutex
+}
+
+// NewAuditFileSink returns a sink appending to the file, which is created if it does not exist
+func NewAuditFileSink(file string) *AuditFileSink {
+	return &AuditFileSink{file: file}
+}
+
+// Notify appends the records to the audit file
+func (s *AuditFileSink) Notify(ctx context.Context, records []EvictionRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.OpenFile(s.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open audit file %q: %v", s.file, err)
+	}
+	encoder := json.NewEncoder(f)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			f.Close()
+			return fmt.Errorf("unable to write audit file %q: %v", s.file, err)
+		}
+	}
+	return f.Close()
+}
+
+// WebhookSink posts the evictions of a cycle to an HTTP(S) endpoint as a single batch,
+// a JSON object whose evictions field lists the EvictionRecords
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting to the url, which must be an absolute http or https URL
+func NewWebhookSink(webhookURL string) (*WebhookSink, error) {
+	if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("notification webhook URL %q must be an absolute http or https URL", webhookURL)
+	}
+	return &WebhookSink{url: webhookURL, client: &http.Client{Timeout: notificationTimeout}}, nil
+}
+
+// Notify posts the records to the webhook, nothing is sent for a cycle without evictions
+func (s *WebhookSink) Notify(ctx context.Context, records []EvictionRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(struct {
+		Evictions []EvictionRecord `json:"evictions"`
+	}{Evictions: records})
+	if err != nil {
+		return fmt.Errorf("unable to encode evictions: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification webhook answered with status %q", resp.Status)
+	}
+	return nil
+}
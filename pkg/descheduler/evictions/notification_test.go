@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testEvictionRecords() []EvictionRecord {
+	now := time.Now().UTC().Truncate(time.Second)
+	return []EvictionRecord{
+		{Time: now, Namespace: "default", Pod: "p1", Owner: "ReplicaSet/rs-1", Node: "node1", Strategy: "PodLifeTime", DisruptionMode: DisruptionModeEvict},
+		{Time: now, Namespace: "default", Pod: "p2", Node: "node1", Strategy: "RemoveDuplicates", Reason: "duplicate", DisruptionMode: DisruptionModeDelete, DryRun: true},
+	}
+}
+
+func TestAuditFileSink(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "descheduler-audit")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "audit.jsonl")
+
+	sink := NewAuditFileSink(file)
+	records := testEvictionRecords()
+	for _, batch := range [][]EvictionRecord{records[:1], nil, records[1:]} {
+		if err := sink.Notify(ctx, batch); err != nil {
+			t.Fatalf("Unable to write audit file: %v", err)
+		}
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("Unable to open audit file: %v", err)
+	}
+	defer f.Close()
+	var written []EvictionRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		record := EvictionRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Unable to decode audit line %q: %v", scanner.Text(), err)
+		}
+		written = append(written, record)
+	}
+	if !reflect.DeepEqual(written, records) {
+		t.Errorf("Expected audit records %+v, got %+v", records, written)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	ctx := context.Background()
+	var batches [][]EvictionRecord
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batch := struct {
+			Evictions []EvictionRecord `json:"evictions"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("Unable to decode evictions: %v", err)
+		}
+		batches = append(batches, batch.Evictions)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(server.URL)
+	if err != nil {
+		t.Fatalf("Unable to create webhook sink: %v", err)
+	}
+	records := testEvictionRecords()
+	if err := sink.Notify(ctx, records); err != nil {
+		t.Errorf("Unable to notify webhook: %v", err)
+	}
+	// cycles without evictions are not sent
+	if err := sink.Notify(ctx, nil); err != nil {
+		t.Errorf("Unable to notify webhook: %v", err)
+	}
+	if !reflect.DeepEqual(batches, [][]EvictionRecord{records}) {
+		t.Errorf("Expected a single batch %+v, got %+v", records, batches)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sink.Notify(ctx, records); err == nil {
+		t.Errorf("Expected an error when the webhook fails")
+	}
+
+	if _, err := NewWebhookSink("cmdb.example.com/evictions"); err == nil {
+		t.Errorf("Expected an error for a URL without scheme")
+	}
+}
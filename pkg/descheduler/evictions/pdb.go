@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	"k8s.io/klog/v2"
+)
+
+// WithPodDisruptionBudgets checks the PodDisruptionBudgets of a pod before it is evicted.
+// Every eviction in the cycle takes one of the disruptions a budget allowed at the start
+// of the cycle, pods which would exceed a budget are skipped without calling the API.
+func WithPodDisruptionBudgets(pdbLister policylisters.PodDisruptionBudgetLister) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.pdbLister = pdbLister
+		pe.disruptionsAllowed = make(map[types.NamespacedName]int32)
+	}
+}
+
+// podDisruptionBudgets returns the budgets the eviction of the pod counts against.
+// Pending and terminated pods do not count, the API allows evicting them regardless of the budgets.
+func (pe *PodEvictor) podDisruptionBudgets(pod *v1.Pod) []*policy.PodDisruptionBudget {
+	if pe.pdbLister == nil {
+		return nil
+	}
+	switch pod.Status.Phase {
+	case v1.PodPending, v1.PodSucceeded, v1.PodFailed:
+		return nil
+	}
+	pdbs, err := pe.pdbLister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Unable to list PodDisruptionBudgets, not checking them before the eviction", "pod", klog.KObj(pod))
+		return nil
+	}
+
+	var matching []*policy.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		// a budget without a selector or with an empty one selects no pods
+		if pdb.Spec.Selector == nil || len(pdb.Spec.Selector.MatchLabels)+len(pdb.Spec.Selector.MatchExpressions) == 0 {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			klog.ErrorS(err, "Invalid selector of PodDisruptionBudget", "podDisruptionBudget", klog.KObj(pdb))
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pdb)
+		}
+	}
+	return matching
+}
+
+// takeDisruptions takes a disruption from every budget, or returns the first budget which
+// does not allow another disruption. It is called with the lock held.
+func (pe *PodEvictor) takeDisruptions(pdbs []*policy.PodDisruptionBudget) *policy.PodDisruptionBudget {
+	for _, pdb := range pdbs {
+		key := types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}
+		if _, ok := pe.disruptionsAllowed[key]; !ok {
+			pe.disruptionsAllowed[key] = pdb.Status.DisruptionsAllowed
+		}
+		if pe.disruptionsAllowed[key] <= 0 {
+			return pdb
+		}
+	}
+	for _, pdb := range pdbs {
+		pe.disruptionsAllowed[types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}]--
+	}
+	return nil
+}
+
+// returnDisruptions gives back the disruptions of a failed eviction. It is called with the lock held.
+func (pe *PodEvictor) returnDisruptions(pdbs []*policy.PodDisruptionBudget) {
+	for _, pdb := range pdbs {
+		pe.disruptionsAllowed[types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}]++
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildTestPDB(name string, selector map[string]string, disruptionsAllowed int32) *policy.PodDisruptionBudget {
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Status:     policy.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+	}
+	if selector != nil {
+		pdb.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+	return pdb
+}
+
+func newTestPDBLister(t *testing.T, pdbs ...*policy.PodDisruptionBudget) policylisters.PodDisruptionBudgetLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pdb := range pdbs {
+		if err := indexer.Add(pdb); err != nil {
+			t.Fatalf("Unable to add PodDisruptionBudget: %v", err)
+		}
+	}
+	return policylisters.NewPodDisruptionBudgetLister(indexer)
+}
+
+func TestEvictPodPodDisruptionBudget(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	labelled := func(app string, phase v1.PodPhase) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Labels = map[string]string{"app": app}
+			pod.Status.Phase = phase
+		}
+	}
+	pdbLister := newTestPDBLister(t,
+		buildTestPDB("web", map[string]string{"app": "web"}, 1),
+		buildTestPDB("db", map[string]string{"app": "db"}, 0),
+		buildTestPDB("empty", map[string]string{}, 0),
+		buildTestPDB("none", nil, 0),
+	)
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithPodDisruptionBudgets(pdbLister))
+	for _, tc := range []struct {
+		pod *v1.Pod
+		err error
+	}{
+		{pod: test.BuildTestPod("web-1", 100, 0, "node1", labelled("web", v1.PodRunning))},
+		{pod: test.BuildTestPod("web-2", 100, 0, "node1", labelled("web", v1.PodRunning)), err: ErrPodDisruptionBudget},
+		{pod: test.BuildTestPod("db-1", 100, 0, "node1", labelled("db", v1.PodRunning)), err: ErrPodDisruptionBudget},
+		// the API evicts pending pods regardless of the budget
+		{pod: test.BuildTestPod("db-2", 100, 0, "node1", labelled("db", v1.PodPending))},
+		{pod: test.BuildTestPod("other", 100, 0, "node1", labelled("other", v1.PodRunning))},
+	} {
+		_, err := podEvictor.EvictPod(ctx, tc.pod, node1)
+		if !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+			t.Errorf("Expected error %v evicting pod %v, got %v", tc.err, tc.pod.Name, err)
+		}
+		if err != nil && !IsPodLimitError(err) {
+			t.Errorf("Expected the budget to only skip pod %v", tc.pod.Name)
+		}
+	}
+	if podEvictor.TotalEvicted() != 3 {
+		t.Errorf("Expected 3 evicted pods, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodPodDisruptionBudgetFailedEviction(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	web := func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"app": "web"}
+	}
+	pdbLister := newTestPDBLister(t, buildTestPDB("web", map[string]string{"app": "web"}, 1))
+
+	fakeClient := &fake.Clientset{}
+	failed := false
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" && !failed {
+			failed = true
+			return true, nil, apierrors.NewInternalError(errors.New("unavailable"))
+		}
+		return false, nil, nil
+	})
+	podEvictor := NewPodEvictor(fakeClient, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithPodDisruptionBudgets(pdbLister))
+
+	// the disruption of the failed eviction is given back to the budget
+	for _, name := range []string{"web-1", "web-2"} {
+		if _, err := podEvictor.EvictPod(ctx, test.BuildTestPod(name, 100, 0, "node1", web), node1); err != nil {
+			t.Errorf("Unable to evict pod %v: %v", name, err)
+		}
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 evicted pod, got %v", podEvictor.TotalEvicted())
+	}
+}
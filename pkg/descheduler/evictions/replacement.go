@@ -0,0 +1,222 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// replacementPollInterval is how often the ready pods of an owner are counted while
+// an eviction waits for the replacement of an earlier evicted pod
+var replacementPollInterval = time.Second
+
+// WithReplacementWait paces the evictions of the pods of a ReplicaSet or StatefulSet.
+// After a pod was evicted, the next eviction of a pod of the same owner waits until the
+// owner has as many ready pods as before its first eviction in the cycle. EvictPod returns
+// ErrEvictionDeferred for it and the pods of other owners are evicted in the meantime. All
+// deferred evictions of the cycle must be made within the timeout after the first one was
+// deferred, those which are not are given up and left to the next cycle. The ready pods are
+// counted from the podLister. Evictions in dry run mode are not paced. With an eviction
+// cooldown at most one pod of an owner is evicted in a cycle, so no eviction is deferred.
+func WithReplacementWait(timeout time.Duration, podLister corelisters.PodLister) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.replacementTimeout = timeout
+		pe.podLister = podLister
+		pe.pacedOwners = make(map[string]*pacedOwner)
+	}
+}
+
+// pacedOwner keeps the state of the evictions of an owner whose evictions are paced
+type pacedOwner struct {
+	ref       metav1.OwnerReference
+	namespace string
+	// selector and uid select the pods of the owner, they are read from the owner once
+	selector labels.Selector
+	uid      types.UID
+	// evictedPods are the names of the evicted pods of the owner, which are not counted as
+	// ready even before the pod lister sees them being deleted
+	evictedPods map[string]bool
+	// readyPods is the number of ready pods before the first eviction, or -1 if it is
+	// unknown, in which case the evictions of the owner are not paced
+	readyPods int
+	// evicted is closed once the last eviction of the owner is made or given up
+	evicted chan struct{}
+}
+
+// pacedOwnerRef returns the controller of the pod if its evictions can be paced
+func pacedOwnerRef(pod *v1.Pod) *metav1.OwnerReference {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil || (ownerRef.Kind != "ReplicaSet" && ownerRef.Kind != "StatefulSet") {
+		return nil
+	}
+	return ownerRef
+}
+
+// WaitForDeferredEvictions waits until the evictions deferred until a replacement pod
+// is ready are made or given up.
+func (pe *PodEvictor) WaitForDeferredEvictions() {
+	pe.deferred.Wait()
+}
+
+// evictPaced makes the first eviction of an owner right away, and defers the later ones
+// until the replacement of the previously evicted pod is ready.
+func (pe *PodEvictor) evictPaced(ctx context.Context, pod *v1.Pod, node *v1.Node, pdbs []*policy.PodDisruptionBudget, reasons []string, ownerRef *metav1.OwnerReference) (bool, error) {
+	key := ownerKey(pod)
+	evicted := make(chan struct{})
+	pe.lock.Lock()
+	owner, ok := pe.pacedOwners[key]
+	if !ok {
+		owner = &pacedOwner{ref: *ownerRef, namespace: pod.Namespace, evictedPods: make(map[string]bool)}
+		pe.pacedOwners[key] = owner
+	}
+	previous := owner.evicted
+	owner.evicted = evicted
+	pe.lock.Unlock()
+
+	if previous == nil {
+		defer close(evicted)
+		readyPods, err := pe.countReadyPods(ctx, owner)
+		if err != nil {
+			klog.ErrorS(err, "Unable to count the ready pods of the owner, not waiting for replacements", "pod", klog.KObj(pod), "owner", owner.ref.Name)
+			readyPods = -1
+		}
+		owner.readyPods = readyPods
+		success, err := pe.evict(ctx, pod, node, pdbs, reasons)
+		if success {
+			owner.evictedPods[pod.Name] = true
+		}
+		return success, err
+	}
+
+	// later strategies of the cycle must not pick the pod while its eviction is deferred
+	if snapshot := podutil.SnapshotFrom(ctx); snapshot != nil {
+		snapshot.MarkEvicted(pod)
+	}
+	pe.lock.Lock()
+	if pe.deferralDeadline.IsZero() {
+		pe.deferralDeadline = time.Now().Add(pe.replacementTimeout)
+	}
+	deadline := pe.deferralDeadline
+	pe.lock.Unlock()
+	klog.V(2).InfoS("Deferring eviction until the replacement of the previously evicted pod of the owner is ready", "pod", klog.KObj(pod), "owner", owner.ref.Name)
+	pe.deferred.Add(1)
+	go func() {
+		defer pe.deferred.Done()
+		defer close(evicted)
+		<-previous
+		if err := pe.waitForReplacement(ctx, owner, deadline); err != nil {
+			klog.ErrorS(err, "Not evicting pod, the replacement of the previously evicted pod of the owner is not ready", "pod", klog.KObj(pod), "owner", owner.ref.Name)
+			pe.lock.Lock()
+			pe.release(pod, node, pdbs)
+			pe.lock.Unlock()
+			return
+		}
+		success, err := pe.evict(ctx, pod, node, pdbs, reasons)
+		if err != nil {
+			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+		}
+		if success {
+			owner.evictedPods[pod.Name] = true
+		}
+	}()
+	return false, fmt.Errorf("%w (pod %q of %v %q)", ErrEvictionDeferred, pod.Name, owner.ref.Kind, owner.ref.Name)
+}
+
+// waitForReplacement waits until the owner has as many ready pods as before its first eviction,
+// or until the deadline of the deferred evictions of the cycle
+func (pe *PodEvictor) waitForReplacement(ctx context.Context, owner *pacedOwner, deadline time.Time) error {
+	if owner.readyPods < 0 {
+		return nil
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	err := wait.PollImmediateUntil(replacementPollInterval, func() (bool, error) {
+		readyPods, err := pe.countReadyPods(ctx, owner)
+		if err != nil {
+			klog.V(2).InfoS("Unable to count the ready pods of the owner", "owner", owner.ref.Name, "err", err)
+			return false, nil
+		}
+		return readyPods >= owner.readyPods, nil
+	}, ctx.Done())
+	if err != nil {
+		return fmt.Errorf("%s %s/%s does not have %v ready pods again within the replacement timeout of %v: %w", owner.ref.Kind, owner.namespace, owner.ref.Name, owner.readyPods, pe.replacementTimeout, err)
+	}
+	return nil
+}
+
+// countReadyPods counts the ready pods of the owner. Pods which are being deleted, like
+// an evicted pod, are not counted. The evictions of an owner are made one after another,
+// so its state is not accessed concurrently.
+func (pe *PodEvictor) countReadyPods(ctx context.Context, owner *pacedOwner) (int, error) {
+	if owner.selector == nil {
+		var selector *metav1.LabelSelector
+		switch owner.ref.Kind {
+		case "ReplicaSet":
+			rs, err := pe.client.AppsV1().ReplicaSets(owner.namespace).Get(ctx, owner.ref.Name, metav1.GetOptions{})
+			if err != nil {
+				return 0, err
+			}
+			selector, owner.uid = rs.Spec.Selector, rs.UID
+		case "StatefulSet":
+			ss, err := pe.client.AppsV1().StatefulSets(owner.namespace).Get(ctx, owner.ref.Name, metav1.GetOptions{})
+			if err != nil {
+				return 0, err
+			}
+			selector, owner.uid = ss.Spec.Selector, ss.UID
+		default:
+			return 0, fmt.Errorf("unsupported owner kind %v", owner.ref.Kind)
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return 0, err
+		}
+		owner.selector = labelSelector
+	}
+
+	pods, err := pe.podLister.Pods(owner.namespace).List(owner.selector)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+	for _, pod := range pods {
+		ownerRef := metav1.GetControllerOf(pod)
+		if ownerRef == nil || ownerRef.UID != owner.uid || pod.DeletionTimestamp != nil || owner.evictedPods[pod.Name] {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+	return ready, nil
+}
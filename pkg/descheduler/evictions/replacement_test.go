@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildTestReplicaSet(name string) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+		Spec:       appsv1.ReplicaSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}},
+	}
+}
+
+func buildReadyTestPod(name, nodeName string, rs *appsv1.ReplicaSet) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.Labels = rs.Spec.Selector.MatchLabels
+		pod.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(rs, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))}
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	})
+}
+
+// startPodLister returns a lister of the pods of the client, which follows the changes of the pods until stopCh is closed
+func startPodLister(client *fake.Clientset, stopCh <-chan struct{}) corelisters.PodLister {
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+	sharedInformerFactory.Start(stopCh)
+	sharedInformerFactory.WaitForCacheSync(stopCh)
+	return podLister
+}
+
+// recordEvictions marks evicted pods as being deleted and returns the names of the evicted pods
+func recordEvictions(client *fake.Clientset) func() []string {
+	var lock sync.Mutex
+	var evicted []string
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(core.CreateAction).GetObject().(metav1.Object).GetName()
+		obj, err := client.Tracker().Get(v1.SchemeGroupVersion.WithResource("pods"), "default", name)
+		if err != nil {
+			return true, nil, err
+		}
+		pod := obj.(*v1.Pod)
+		now := metav1.Now()
+		pod.DeletionTimestamp = &now
+		if err := client.Tracker().Update(v1.SchemeGroupVersion.WithResource("pods"), pod, "default"); err != nil {
+			return true, nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		evicted = append(evicted, name)
+		return true, nil, nil
+	})
+	return func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return evicted
+	}
+}
+
+func TestEvictPodReplacementWait(t *testing.T) {
+	defer func(interval time.Duration) { replacementPollInterval = interval }(replacementPollInterval)
+	replacementPollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	tests := []struct {
+		description string
+		replaced    bool
+		evicted     []string
+	}{
+		{
+			description: "the next eviction of the owner waits for the replacement",
+			replaced:    true,
+			evicted:     []string{"web-1", "db-1", "web-2"},
+		},
+		{
+			description: "the next eviction of the owner is given up without a replacement",
+			evicted:     []string{"web-1", "db-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		web := buildTestReplicaSet("web")
+		db := buildTestReplicaSet("db")
+		web1, web2, db1 := buildReadyTestPod("web-1", node1.Name, web), buildReadyTestPod("web-2", node1.Name, web), buildReadyTestPod("db-1", node1.Name, db)
+		client := fake.NewSimpleClientset(web, db, web1, web2, db1)
+		evicted := recordEvictions(client)
+
+		stopCh := make(chan struct{})
+		podEvictor := NewPodEvictor(client, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithReplacementWait(200*time.Millisecond, startPodLister(client, stopCh)))
+		for _, pod := range []*v1.Pod{web1, web2, db1} {
+			success, err := podEvictor.EvictPod(ctx, pod, node1)
+			// the deferred eviction is not counted as made, it may be given up
+			if pod == web2 {
+				if success || !errors.Is(err, ErrEvictionDeferred) || !IsPodLimitError(err) {
+					t.Errorf("%v: expected the eviction of pod %v to be deferred, got success %v and error %v", tc.description, pod.Name, success, err)
+				}
+				continue
+			}
+			if !success || err != nil {
+				t.Errorf("%v: expected pod %v to be evicted, got success %v and error %v", tc.description, pod.Name, success, err)
+			}
+		}
+
+		if tc.replaced {
+			if err := client.Tracker().Add(buildReadyTestPod("web-3", node1.Name, web)); err != nil {
+				t.Fatalf("%v: unable to add the replacement pod: %v", tc.description, err)
+			}
+		}
+		podEvictor.WaitForDeferredEvictions()
+
+		if !reflect.DeepEqual(evicted(), tc.evicted) {
+			t.Errorf("%v: expected evictions %v, got %v", tc.description, tc.evicted, evicted())
+		}
+		if podEvictor.TotalEvicted() != len(tc.evicted) {
+			t.Errorf("%v: expected %v evicted pods, got %v", tc.description, len(tc.evicted), podEvictor.TotalEvicted())
+		}
+		// the ready pods are counted from the pod lister, only its informer lists the pods
+		lists := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				lists++
+			}
+		}
+		if lists != 1 {
+			t.Errorf("%v: expected the pods to be listed once, got %v lists", tc.description, lists)
+		}
+		close(stopCh)
+	}
+}
+
+func TestEvictPodReplacementWaitCycleDeadline(t *testing.T) {
+	defer func(interval time.Duration) { replacementPollInterval = interval }(replacementPollInterval)
+	replacementPollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	web := buildTestReplicaSet("web")
+	objs := []runtime.Object{web}
+	var pods []*v1.Pod
+	for _, name := range []string{"web-1", "web-2", "web-3", "web-4"} {
+		pod := buildReadyTestPod(name, node1.Name, web)
+		pods = append(pods, pod)
+		objs = append(objs, pod)
+	}
+	client := fake.NewSimpleClientset(objs...)
+	evicted := recordEvictions(client)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	timeout := 300 * time.Millisecond
+	podEvictor := NewPodEvictor(client, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithReplacementWait(timeout, startPodLister(client, stopCh)))
+	start := time.Now()
+	for _, pod := range pods {
+		podEvictor.EvictPod(ctx, pod, node1)
+	}
+	podEvictor.WaitForDeferredEvictions()
+
+	// the three deferred evictions share one timeout instead of waiting one after another
+	if elapsed := time.Since(start); elapsed > 2*timeout {
+		t.Errorf("Expected the deferred evictions to be given up after %v, it took %v", timeout, elapsed)
+	}
+	if expected := []string{"web-1"}; !reflect.DeepEqual(evicted(), expected) {
+		t.Errorf("Expected evictions %v, got %v", expected, evicted())
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 evicted pod, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodReplacementWaitWithCooldown(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	web := buildTestReplicaSet("web")
+	db := buildTestReplicaSet("db")
+	web1, web2, db1 := buildReadyTestPod("web-1", node1.Name, web), buildReadyTestPod("web-2", node1.Name, web), buildReadyTestPod("db-1", node1.Name, db)
+	client := fake.NewSimpleClientset(web, db, web1, web2, db1)
+	evicted := recordEvictions(client)
+
+	history, err := LoadEvictionHistory(ctx, client, "kube-system", "descheduler-eviction-history")
+	if err != nil {
+		t.Fatalf("Unable to load the eviction history: %v", err)
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	podEvictor := NewPodEvictor(client, "v1beta1", false, 0, []*v1.Node{node1}, false, false,
+		WithEvictionHistory(history, time.Hour), WithReplacementWait(time.Hour, startPodLister(client, stopCh)))
+
+	if success, err := podEvictor.EvictPod(ctx, web1, node1); !success || err != nil {
+		t.Errorf("Expected pod %v to be evicted, got success %v and error %v", web1.Name, success, err)
+	}
+	// the cooldown allows one eviction of the owner in the cycle, so nothing is deferred
+	if success, err := podEvictor.EvictPod(ctx, web2, node1); success || !errors.Is(err, ErrOwnerCooldown) {
+		t.Errorf("Expected pod %v to be skipped for the cooldown, got success %v and error %v", web2.Name, success, err)
+	}
+	if success, err := podEvictor.EvictPod(ctx, db1, node1); !success || err != nil {
+		t.Errorf("Expected pod %v to be evicted, got success %v and error %v", db1.Name, success, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		podEvictor.WaitForDeferredEvictions()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected no deferred eviction")
+	}
+	if expected := []string{"web-1", "db-1"}; !reflect.DeepEqual(evicted(), expected) {
+		t.Errorf("Expected evictions %v, got %v", expected, evicted())
+	}
+}
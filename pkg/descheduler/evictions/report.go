@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ReportFormatJSON  = "json"
+	ReportFormatYAML  = "yaml"
+	ReportFormatTable = "table"
+)
+
+// ReportFormats lists the formats a dry run report can be written in
+var ReportFormats = []string{ReportFormatJSON, ReportFormatYAML, ReportFormatTable}
+
+// ValidateReportFormat returns an error if the format is not one of the ReportFormats
+func ValidateReportFormat(format string) error {
+	for _, f := range ReportFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown dry run report format %q, supported formats are %v", format, ReportFormats)
+}
+
+// EvictionRecord describes an eviction made in a descheduling cycle, or planned in dry run mode.
+// The dry run report and the notifications list the same records.
+type EvictionRecord struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	// Owner is the kind and name of the controller of the pod, e.g. ReplicaSet/nginx-5d8f4f4b8
+	Owner          string         `json:"owner,omitempty"`
+	Node           string         `json:"node"`
+	Strategy       string         `json:"strategy,omitempty"`
+	Reason         string         `json:"reason,omitempty"`
+	Priority       *int32         `json:"priority,omitempty"`
+	DisruptionMode DisruptionMode `json:"disruptionMode"`
+	DryRun         bool           `json:"dryRun"`
+}
+
+// DryRunReport lists the evictions planned in a descheduling cycle in dry run mode
+type DryRunReport struct {
+	Evictions []EvictionRecord `json:"evictions"`
+}
+
+func newEvictionRecord(ctx context.Context, pod *v1.Pod, node *v1.Node, reasons []string, dryRun bool) EvictionRecord {
+	record := EvictionRecord{
+		Time:           time.Now(),
+		Namespace:      pod.Namespace,
+		Pod:            pod.Name,
+		Node:           node.Name,
+		Strategy:       strategyName(ctx),
+		Reason:         strings.Join(reasons, ", "),
+		Priority:       pod.Spec.Priority,
+		DisruptionMode: disruptionMode(ctx),
+		DryRun:         dryRun,
+	}
+	if ownerRef := podOwner(pod); ownerRef != nil {
+		record.Owner = ownerRef.Kind + "/" + ownerRef.Name
+	}
+	return record
+}
+
+// WriteDryRunReport writes the report in one of the ReportFormats
+func WriteDryRunReport(w io.Writer, format string, report DryRunReport) error {
+	if report.Evictions == nil {
+		report.Evictions = []EvictionRecord{}
+	}
+	switch format {
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case ReportFormatYAML:
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case ReportFormatTable:
+		tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tPOD\tOWNER\tNODE\tSTRATEGY\tPRIORITY\tREASON")
+		for _, e := range report.Evictions {
+			priority := "<none>"
+			if e.Priority != nil {
+				priority = fmt.Sprint(*e.Priority)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Namespace, e.Pod, valueOrNone(e.Owner), e.Node, valueOrNone(e.Strategy), priority, valueOrNone(e.Reason))
+		}
+		return tw.Flush()
+	}
+	return ValidateReportFormat(format)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEvictionRecords(t *testing.T) {
+	ctx := WithStrategyName(context.Background(), "TestEvictionRecords")
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	pod1.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+	priority := int32(100)
+	pod1.Spec.Priority = &priority
+	pod2 := test.BuildTestPod("p2", 400, 0, "node1", nil)
+	// the controller is the owner, even if it is not the first owner reference
+	isController := true
+	pod3 := test.BuildTestPod("p3", 400, 0, "node1", nil)
+	pod3.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
+		{Kind: "ConfigMap", APIVersion: "v1", Name: "config"},
+		{Kind: "StatefulSet", APIVersion: "apps/v1", Name: "db", Controller: &isController},
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", dryRun, 0, []*v1.Node{node1}, false, false)
+		for _, pod := range []*v1.Pod{pod1, pod2, pod3} {
+			if success, err := podEvictor.EvictPod(ctx, pod, node1, "TestReason"); !success || err != nil {
+				t.Fatalf("Expected pod %v to be evicted, got %v, %v", pod.Name, success, err)
+			}
+		}
+
+		expected := []EvictionRecord{
+			{Namespace: "default", Pod: "p1", Owner: "ReplicaSet/replicaset-1", Node: "node1", Strategy: "TestEvictionRecords", Reason: "TestReason", Priority: &priority, DisruptionMode: DisruptionModeEvict, DryRun: dryRun},
+			{Namespace: "default", Pod: "p2", Node: "node1", Strategy: "TestEvictionRecords", Reason: "TestReason", DisruptionMode: DisruptionModeEvict, DryRun: dryRun},
+			{Namespace: "default", Pod: "p3", Owner: "StatefulSet/db", Node: "node1", Strategy: "TestEvictionRecords", Reason: "TestReason", DisruptionMode: DisruptionModeEvict, DryRun: dryRun},
+		}
+		records := podEvictor.EvictionRecords()
+		for i := range records {
+			if records[i].Time.IsZero() {
+				t.Errorf("Dry run %v: expected the time of eviction record %v to be set", dryRun, i)
+			}
+			records[i].Time = time.Time{}
+		}
+		if !reflect.DeepEqual(records, expected) {
+			t.Errorf("Dry run %v: expected eviction records %+v, got %+v", dryRun, expected, records)
+		}
+	}
+}
+
+func TestWriteDryRunReport(t *testing.T) {
+	priority := int32(100)
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	report := DryRunReport{Evictions: []EvictionRecord{
+		{Time: now, Namespace: "default", Pod: "p1", Owner: "ReplicaSet/rs", Node: "node1", Strategy: "PodLifeTime", Reason: "PodLifeTime", Priority: &priority, DisruptionMode: DisruptionModeEvict, DryRun: true},
+		{Time: now, Namespace: "default", Pod: "p2", Node: "node1", Strategy: "RemoveDuplicates", DisruptionMode: DisruptionModeDelete, DryRun: true},
+	}}
+
+	tests := []struct {
+		format   string
+		report   DryRunReport
+		expected string
+		err      bool
+	}{
+		{
+			format: ReportFormatJSON,
+			report: report,
+			expected: `{
+  "evictions": [
+    {
+      "time": "2021-03-01T12:00:00Z",
+      "namespace": "default",
+      "pod": "p1",
+      "owner": "ReplicaSet/rs",
+      "node": "node1",
+      "strategy": "PodLifeTime",
+      "reason": "PodLifeTime",
+      "priority": 100,
+      "disruptionMode": "Evict",
+      "dryRun": true
+    },
+    {
+      "time": "2021-03-01T12:00:00Z",
+      "namespace": "default",
+      "pod": "p2",
+      "node": "node1",
+      "strategy": "RemoveDuplicates",
+      "disruptionMode": "Delete",
+      "dryRun": true
+    }
+  ]
+}
+`,
+		},
+		{
+			format:   ReportFormatJSON,
+			report:   DryRunReport{},
+			expected: "{\n  \"evictions\": []\n}\n",
+		},
+		{
+			format: ReportFormatYAML,
+			report: report,
+			expected: `evictions:
+- disruptionMode: Evict
+  dryRun: true
+  namespace: default
+  node: node1
+  owner: ReplicaSet/rs
+  pod: p1
+  priority: 100
+  reason: PodLifeTime
+  strategy: PodLifeTime
+  time: "2021-03-01T12:00:00Z"
+- disruptionMode: Delete
+  dryRun: true
+  namespace: default
+  node: node1
+  pod: p2
+  strategy: RemoveDuplicates
+  time: "2021-03-01T12:00:00Z"
+`,
+		},
+		{
+			format: ReportFormatTable,
+			report: report,
+			expected: `NAMESPACE   POD   OWNER           NODE    STRATEGY           PRIORITY   REASON
+default     p1    ReplicaSet/rs   node1   PodLifeTime        100        PodLifeTime
+default     p2    <none>          node1   RemoveDuplicates   <none>     <none>
+`,
+		},
+		{
+			format: "xml",
+			report: report,
+			err:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		var out bytes.Buffer
+		err := WriteDryRunReport(&out, tc.format, tc.report)
+		if (err != nil) != tc.err {
+			t.Errorf("%v: unexpected error: %v", tc.format, err)
+			continue
+		}
+		if out.String() != tc.expected {
+			t.Errorf("%v: expected report\n%v\ngot\n%v", tc.format, tc.expected, out.String())
+		}
+	}
+}
@@ -17,16 +17,25 @@ limitations under the License.
 package utils
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	clientset "k8s.io/client-go/kubernetes"
 )
 
 const (
 	EvictionKind        = "Eviction"
 	EvictionSubresource = "pods/eviction"
+
+	// PolicyV1GroupVersion is the group version of the policy/v1 Eviction API,
+	// preferred over policy/v1beta1 when the server supports both
+	PolicyV1GroupVersion = "policy/v1"
+	// PolicyV1beta1GroupVersion is the group version of the policy/v1beta1 Eviction API
+	PolicyV1beta1GroupVersion = "policy/v1beta1"
 )
 
 // SupportEviction uses Discovery API to find out if the server support eviction subresource
-// If support, it will return its groupVersion; Otherwise, it will return ""
+// If support, it will return the groupVersion of the Eviction the subresource accepts, policy/v1
+// when the subresource advertises it and policy/v1beta1 otherwise;
+// Otherwise, it will return ""
 func SupportEviction(client clientset.Interface) (string, error) {
 	discoveryClient := client.Discovery()
 	groupList, err := discoveryClient.ServerGroups()
@@ -34,11 +43,9 @@ func SupportEviction(client clientset.Interface) (string, error) {
 		return "", err
 	}
 	foundPolicyGroup := false
-	var policyGroupVersion string
 	for _, group := range groupList.Groups {
 		if group.Name == "policy" {
 			foundPolicyGroup = true
-			policyGroupVersion = group.PreferredVersion.GroupVersion
 			break
 		}
 	}
@@ -51,8 +58,31 @@ func SupportEviction(client clientset.Interface) (string, error) {
 	}
 	for _, resource := range resourceList.APIResources {
 		if resource.Name == EvictionSubresource && resource.Kind == EvictionKind {
-			return policyGroupVersion, nil
+			// The policy group can serve policy/v1 for PodDisruptionBudgets before the
+			// subresource accepts a policy/v1 Eviction, as on Kubernetes 1.21.
+			if resource.Group == "policy" && resource.Version == "v1" {
+				return PolicyV1GroupVersion, nil
+			}
+			return PolicyV1beta1GroupVersion, nil
 		}
 	}
 	return "", nil
 }
+
+// SupportPodDisruptionBudgets uses Discovery API to find out if the server serves
+// policy/v1beta1 PodDisruptionBudgets, which are removed from Kubernetes 1.25 on
+func SupportPodDisruptionBudgets(client clientset.Interface) (bool, error) {
+	resourceList, err := client.Discovery().ServerResourcesForGroupVersion(PolicyV1beta1GroupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, resource := range resourceList.APIResources {
+		if resource.Name == "poddisruptionbudgets" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// FailurePolicy tells what happens to an eviction when the approval webhook does not answer
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail skips the eviction, it is the default
+	FailurePolicyFail FailurePolicy = "Fail"
+	// FailurePolicyIgnore makes the eviction as if the webhook allowed it
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+// DefaultApprovalTimeout is the timeout of an approval request if none is configured
+const DefaultApprovalTimeout = 10 * time.Second
+
+// EvictionReview is sent to the approval webhook for every eviction
+type EvictionReview struct {
+	Pod            *v1.Pod        `json:"pod"`
+	Node           string         `json:"node"`
+	Strategy       string         `json:"strategy"`
+	Reasons        []string       `json:"reasons,omitempty"`
+	DisruptionMode DisruptionMode `json:"disruptionMode"`
+}
+
+// EvictionReviewResponse is the answer of the approval webhook
+type EvictionReviewResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// ApprovalWebhook asks an HTTP(S) endpoint to approve evictions, similar to an admission webhook
+type ApprovalWebhook struct {
+	url           string
+	client        *http.Client
+	failurePolicy FailurePolicy
+}
+
+// NewApprovalWebhook returns a webhook posting EvictionReviews to the url. The certificate of an
+// HTTPS endpoint is verified with the PEM encoded caBundle, or the system trust roots if it is empty.
+func NewApprovalWebhook(url string, caBundle []byte, timeout time.Duration, failurePolicy FailurePolicy) (*ApprovalWebhook, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in the CA bundle of the approval webhook")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	if timeout <= 0 {
+		timeout = DefaultApprovalTimeout
+	}
+	if failurePolicy == "" {
+		failurePolicy = FailurePolicyFail
+	}
+	return &ApprovalWebhook{
+		url:           url,
+		client:        &http.Client{Transport: transport, Timeout: timeout},
+		failurePolicy: failurePolicy,
+	}, nil
+}
+
+// WithApprovalWebhook asks the webhook to approve every eviction right before it is made.
+// Denied evictions are skipped, they do not count against the limits.
+func WithApprovalWebhook(webhook *ApprovalWebhook) func(pe *PodEvictor) {
+	return func(pe *PodEvictor) {
+		pe.approvalWebhook = webhook
+	}
+}
+
+// review posts the review to the webhook and returns its answer
+func (w *ApprovalWebhook) review(ctx context.Context, review *EvictionReview) (*EvictionReviewResponse, error) {
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode eviction review: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("approval webhook answered with status %q", resp.Status)
+	}
+	response := &EvictionReviewResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("unable to decode the answer of the approval webhook: %v", err)
+	}
+	return response, nil
+}
+
+// approved tells whether the approval webhook allows evicting the pod, applying the failure
+// policy when the webhook does not answer. Pods are always approved without a webhook, and in
+// dry run mode, e.g. when simulating a policy offline, the webhook is not asked.
+func (pe *PodEvictor) approved(ctx context.Context, pod *v1.Pod, node *v1.Node, reasons []string) bool {
+	if pe.approvalWebhook == nil || pe.dryRun {
+		return true
+	}
+	response, err := pe.approvalWebhook.review(ctx, &EvictionReview{
+		Pod:            pod,
+		Node:           node.Name,
+		Strategy:       strategyName(ctx),
+		Reasons:        reasons,
+		DisruptionMode: disruptionMode(ctx),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		if pe.approvalWebhook.failurePolicy == FailurePolicyIgnore {
+			klog.ErrorS(err, "Approval webhook failed, evicting pod as the failure policy is Ignore", "pod", klog.KObj(pod))
+			return true
+		}
+		klog.ErrorS(err, "Approval webhook failed, not evicting pod", "pod", klog.KObj(pod))
+		return false
+	}
+	if !response.Allowed {
+		klog.V(1).InfoS("Eviction denied by the approval webhook", "pod", klog.KObj(pod), "reason", response.Reason)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+// newTestApprovalServer answers the reviews of pods named "allowed-*" with allowed, "denied-*" with
+// denied, "slow-*" too late and any other pod with an internal server error
+func newTestApprovalServer(t *testing.T, tls bool) (*httptest.Server, func() []EvictionReview) {
+	var lock sync.Mutex
+	var reviews []EvictionReview
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := EvictionReview{}
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			t.Errorf("Unable to decode eviction review: %v", err)
+		}
+		lock.Lock()
+		reviews = append(reviews, review)
+		lock.Unlock()
+
+		name := review.Pod.Name
+		switch {
+		case strings.HasPrefix(name, "allowed-"):
+			json.NewEncoder(w).Encode(EvictionReviewResponse{Allowed: true})
+		case strings.HasPrefix(name, "denied-"):
+			json.NewEncoder(w).Encode(EvictionReviewResponse{Allowed: false, Reason: "change freeze"})
+		case strings.HasPrefix(name, "slow-"):
+			time.Sleep(500 * time.Millisecond)
+			json.NewEncoder(w).Encode(EvictionReviewResponse{Allowed: true})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	var server *httptest.Server
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	return server, func() []EvictionReview {
+		lock.Lock()
+		defer lock.Unlock()
+		return reviews
+	}
+}
+
+func TestEvictPodApprovalWebhook(t *testing.T) {
+	ctx := WithStrategyName(context.Background(), "TestEvictPodApprovalWebhook")
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	server, reviews := newTestApprovalServer(t, false)
+	defer server.Close()
+
+	for _, tc := range []struct {
+		description   string
+		failurePolicy FailurePolicy
+		pod           *v1.Pod
+		dryRun        bool
+		success       bool
+	}{
+		{description: "allowed", pod: test.BuildTestPod("allowed-1", 100, 0, "node1", nil), success: true},
+		{description: "denied", pod: test.BuildTestPod("denied-1", 100, 0, "node1", nil)},
+		{description: "failure fails closed", failurePolicy: FailurePolicyFail, pod: test.BuildTestPod("failing-1", 100, 0, "node1", nil)},
+		{description: "failure fails open", failurePolicy: FailurePolicyIgnore, pod: test.BuildTestPod("failing-2", 100, 0, "node1", nil), success: true},
+		{description: "timeout fails closed", pod: test.BuildTestPod("slow-1", 100, 0, "node1", nil)},
+		{description: "timeout fails open", failurePolicy: FailurePolicyIgnore, pod: test.BuildTestPod("slow-2", 100, 0, "node1", nil), success: true},
+		{description: "dry run does not ask", pod: test.BuildTestPod("denied-2", 100, 0, "node1", nil), dryRun: true, success: true},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			webhook, err := NewApprovalWebhook(server.URL, nil, 100*time.Millisecond, tc.failurePolicy)
+			if err != nil {
+				t.Fatalf("Unable to create approval webhook: %v", err)
+			}
+			fakeClient := &fake.Clientset{}
+			podEvictor := NewPodEvictor(fakeClient, "v1beta1", tc.dryRun, 0, []*v1.Node{node1}, false, false, WithApprovalWebhook(webhook))
+			success, err := podEvictor.EvictPod(ctx, tc.pod, node1, "TestReason")
+			if success != tc.success || err != nil {
+				t.Errorf("Expected pod %v to be evicted %v, got %v, %v", tc.pod.Name, tc.success, success, err)
+			}
+			evicted := 0
+			if tc.success {
+				evicted = 1
+			}
+			// a denied eviction does not count against the limits
+			if podEvictor.TotalEvicted() != evicted {
+				t.Errorf("Expected %v pods to be evicted, got %v", evicted, podEvictor.TotalEvicted())
+			}
+			if tc.dryRun {
+				evicted = 0
+			}
+			if len(fakeClient.Actions()) != evicted {
+				t.Errorf("Expected %v evictions, got %v", evicted, fakeClient.Actions())
+			}
+		})
+	}
+
+	for _, review := range reviews() {
+		if review.Pod.Name == "denied-2" {
+			t.Errorf("Expected the webhook not to be asked in dry run mode, got %+v", review)
+		}
+	}
+	review := reviews()[0]
+	expected := EvictionReview{
+		Pod:            review.Pod,
+		Node:           "node1",
+		Strategy:       "TestEvictPodApprovalWebhook",
+		Reasons:        []string{"TestReason"},
+		DisruptionMode: DisruptionModeEvict,
+	}
+	if review.Pod.Name != "allowed-1" || !reflect.DeepEqual(review, expected) {
+		t.Errorf("Expected review %+v, got %+v", expected, review)
+	}
+}
+
+func TestApprovalWebhookCABundle(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("allowed-1", 100, 0, "node1", nil)
+	server, _ := newTestApprovalServer(t, true)
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// the certificate of the test server is not trusted without the CA bundle
+	for _, tc := range []struct {
+		caBundle []byte
+		success  bool
+	}{
+		{caBundle: nil, success: false},
+		{caBundle: caBundle, success: true},
+	} {
+		webhook, err := NewApprovalWebhook(server.URL, tc.caBundle, time.Second, FailurePolicyFail)
+		if err != nil {
+			t.Fatalf("Unable to create approval webhook: %v", err)
+		}
+		podEvictor := NewPodEvictor(&fake.Clientset{}, "v1beta1", false, 0, []*v1.Node{node1}, false, false, WithApprovalWebhook(webhook))
+		if success, err := podEvictor.EvictPod(ctx, pod1, node1); success != tc.success || err != nil {
+			t.Errorf("Expected pod %v to be evicted %v with CA bundle %v, got %v, %v", pod1.Name, tc.success, tc.caBundle != nil, success, err)
+		}
+	}
+
+	if _, err := NewApprovalWebhook(server.URL, []byte("not a certificate"), time.Second, FailurePolicyFail); err == nil {
+		t.Errorf("Expected an error for an invalid CA bundle")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	componentbaseconfig "k8s.io/component-base/config"
+	"k8s.io/klog/v2"
+)
+
+// runWithLeaderElection blocks until the lease is acquired and then calls run.
+// The context passed to run is cancelled once the leadership is lost, in which case
+// an error is returned so the process can exit and leave the work to another replica.
+func runWithLeaderElection(ctx context.Context, client clientset.Interface, config componentbaseconfig.LeaderElectionConfiguration, run func(ctx context.Context) error) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("unable to get hostname: %v", err)
+	}
+	// add a random suffix so replicas sharing a hostname (e.g. with hostNetwork) do not share the identity
+	id := hostname + "_" + utilrand.String(8)
+
+	lock, err := resourcelock.New(
+		config.ResourceLock,
+		config.ResourceNamespace,
+		config.ResourceName,
+		client.CoreV1(),
+		client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: id},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create leader election lock: %v", err)
+	}
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var runErr error
+	leading := make(chan struct{})
+	done := make(chan struct{})
+	lost := false
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.LeaseDuration.Duration,
+		RenewDeadline:   config.RenewDeadline.Duration,
+		RetryPeriod:     config.RetryPeriod.Duration,
+		ReleaseOnCancel: true,
+		Name:            "descheduler",
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.V(1).InfoS("Started leading", "identity", id)
+				close(leading)
+				defer close(done)
+				runErr = run(ctx)
+				// release the lease so a standby replica does not have to wait for it to expire
+				cancel()
+			},
+			OnStoppedLeading: func() {
+				klog.V(1).InfoS("Stopped leading", "identity", id)
+				select {
+				case <-done:
+				default:
+					lost = ctx.Err() == nil
+				}
+			},
+			OnNewLeader: func(identity string) {
+				if identity != id {
+					klog.V(1).InfoS("New leader elected", "identity", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create leader elector: %v", err)
+	}
+
+	klog.V(1).InfoS("Attempting to acquire leader lease", "lock", config.ResourceLock, "namespace", config.ResourceNamespace, "name", config.ResourceName, "identity", id)
+	elector.Run(leaderCtx)
+
+	select {
+	case <-leading:
+	default:
+		// the context was cancelled before the lease was acquired
+		return nil
+	}
+	// the context passed to run is cancelled by now, wait for run to return
+	<-done
+	if lost {
+		return fmt.Errorf("leader election lost")
+	}
+	return runErr
+}
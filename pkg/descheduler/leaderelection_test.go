@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	componentbaseconfig "k8s.io/component-base/config"
+)
+
+func TestRunWithLeaderElection(t *testing.T) {
+	ctx := context.Background()
+	client := fakeclientset.NewSimpleClientset()
+	config := componentbaseconfig.LeaderElectionConfiguration{
+		LeaderElect:       true,
+		LeaseDuration:     metav1.Duration{Duration: 2 * time.Second},
+		RenewDeadline:     metav1.Duration{Duration: time.Second},
+		RetryPeriod:       metav1.Duration{Duration: 100 * time.Millisecond},
+		ResourceLock:      "leases",
+		ResourceName:      "descheduler",
+		ResourceNamespace: "kube-system",
+	}
+
+	var lock sync.Mutex
+	running := 0
+	var order []string
+	run := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			lock.Lock()
+			running++
+			if running > 1 {
+				t.Errorf("More than one replica runs the strategies at the same time")
+			}
+			order = append(order, name)
+			lock.Unlock()
+
+			time.Sleep(500 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"first", "second"} {
+		name := name
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := runWithLeaderElection(ctx, client, config, run(name)); err != nil {
+				t.Errorf("Unexpected error running %v replica: %v", name, err)
+			}
+		}()
+		// give the first replica time to acquire the lease
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Standby replica did not take over the lease")
+	}
+
+	if len(order) != 2 {
+		t.Errorf("Expected both replicas to run once, got %v", order)
+	}
+}
+
+func TestRunWithLeaderElectionCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := fakeclientset.NewSimpleClientset()
+	config := componentbaseconfig.LeaderElectionConfiguration{
+		LeaderElect:       true,
+		LeaseDuration:     metav1.Duration{Duration: 2 * time.Second},
+		RenewDeadline:     metav1.Duration{Duration: time.Second},
+		RetryPeriod:       metav1.Duration{Duration: 100 * time.Millisecond},
+		ResourceLock:      "leases",
+		ResourceName:      "descheduler",
+		ResourceNamespace: "kube-system",
+	}
+
+	result := make(chan error)
+	go func() {
+		result <- runWithLeaderElection(ctx, client, config, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("Expected no error after the context was cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Leader did not stop after the context was cancelled")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
@@ -55,7 +56,8 @@ func WithoutNamespaces(namespaces []string) func(opts *Options) {
 	}
 }
 
-// ListPodsOnANode lists all of the pods on a node
+// ListPodsOnANode lists all of the pods on a node. The pods are read from the
+// snapshot of the context if it has one, otherwise they are listed from the API server.
 // It also accepts an optional "filter" function which can be used to further limit the pods that are returned.
 // (Usually this is podEvictor.Evictable().IsEvictable, in order to only list the evictable pods on a node, but can
 // be used by strategies to extend it if there are further restrictions, such as with NodeAffinity).
@@ -70,6 +72,10 @@ func ListPodsOnANode(
 		opt(options)
 	}
 
+	if snapshot := SnapshotFrom(ctx); snapshot != nil {
+		return filterPods(snapshot.PodsOnNode(node.Name), options), nil
+	}
+
 	pods := make([]*v1.Pod, 0)
 
 	fieldSelectorString := "spec.nodeName=" + node.Name + ",status.phase!=" + string(v1.PodSucceeded) + ",status.phase!=" + string(v1.PodFailed)
@@ -130,6 +136,45 @@ func ListPodsOnANode(
 	return pods, nil
 }
 
+// filterPods filters the pods of a node the same way the field selectors of
+// ListPodsOnANode do on the API server
+func filterPods(nodePods []*v1.Pod, options *Options) []*v1.Pod {
+	included := sets.NewString(options.includedNamespaces...)
+	excluded := sets.NewString(options.excludedNamespaces...)
+	pods := make([]*v1.Pod, 0, len(nodePods))
+	for _, pod := range nodePods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if (included.Len() > 0 && !included.Has(pod.Namespace)) || excluded.Has(pod.Namespace) {
+			continue
+		}
+		if options.filter != nil && !options.filter(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// ListPodsInNamespace lists all pods of a namespace. The pods are read from the
+// snapshot of the context if it has one, otherwise they are listed from the API server.
+func ListPodsInNamespace(ctx context.Context, client clientset.Interface, namespace string) ([]*v1.Pod, error) {
+	if snapshot := SnapshotFrom(ctx); snapshot != nil {
+		return snapshot.PodsInNamespace(namespace), nil
+	}
+
+	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []*v1.Pod{}, err
+	}
+	pods := make([]*v1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods, nil
+}
+
 // OwnerRef returns the ownerRefList for the pod.
 func OwnerRef(pod *v1.Pod) []metav1.OwnerReference {
 	return pod.ObjectMeta.GetOwnerReferences()
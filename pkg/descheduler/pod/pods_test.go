@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -74,6 +75,82 @@ func TestListPodsOnANode(t *testing.T) {
 	}
 }
 
+func TestListPodsOnANodeFromSnapshot(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+	p2 := test.BuildTestPod("p2", 100, 0, n1.Name, func(pod *v1.Pod) {
+		pod.Namespace = "kube-system"
+	})
+	p3 := test.BuildTestPod("p3", 100, 0, n1.Name, func(pod *v1.Pod) {
+		pod.Status.Phase = v1.PodSucceeded
+	})
+	p4 := test.BuildTestPod("p4", 100, 0, n1.Name, func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"filtered": "true"}
+	})
+	p5 := test.BuildTestPod("p5", 100, 0, "n2", nil)
+
+	p6 := test.BuildTestPod("p6", 100, 0, n1.Name, nil)
+
+	fakeClient := &fake.Clientset{}
+	// the pods must be read from the snapshot only
+	fakeClient.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("unexpected list of pods")
+	})
+	snapshot := NewSnapshot([]*v1.Pod{p1, p2, p3, p4, p5, p6})
+	snapshot.MarkEvicted(p6)
+	ctx = WithSnapshot(ctx, snapshot)
+
+	filter := WithFilter(func(pod *v1.Pod) bool {
+		return pod.Labels["filtered"] != "true"
+	})
+	testCases := []struct {
+		name     string
+		opts     []func(opts *Options)
+		expected []string
+	}{
+		{
+			name:     "all pods on the node",
+			expected: []string{"p1", "p2", "p4"},
+		},
+		{
+			name:     "filtered pods",
+			opts:     []func(opts *Options){filter},
+			expected: []string{"p1", "p2"},
+		},
+		{
+			name:     "included namespaces",
+			opts:     []func(opts *Options){WithNamespaces([]string{"kube-system"})},
+			expected: []string{"p2"},
+		},
+		{
+			name:     "excluded namespaces",
+			opts:     []func(opts *Options){WithoutNamespaces([]string{"kube-system"})},
+			expected: []string{"p1", "p4"},
+		},
+	}
+	for _, testCase := range testCases {
+		pods, err := ListPodsOnANode(ctx, fakeClient, n1, testCase.opts...)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", testCase.name, err)
+			continue
+		}
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, testCase.expected) {
+			t.Errorf("%v: expected pods %v, got %v", testCase.name, testCase.expected, names)
+		}
+	}
+
+	pods, err := ListPodsInNamespace(ctx, fakeClient, "kube-system")
+	if err != nil || len(pods) != 1 || pods[0].Name != "p2" {
+		t.Errorf("Expected only pod p2 in namespace kube-system, got %v, %v", pods, err)
+	}
+}
+
 func TestSortPodsBasedOnPriorityLowToHigh(t *testing.T) {
 	n1 := test.BuildTestNode("n1", 4000, 3000, 9, nil)
 
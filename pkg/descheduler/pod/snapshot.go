@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Snapshot holds the pods of the cluster a descheduling cycle works on. It is taken
+// once at the start of the cycle and shared by all strategies of the cycle. Pods
+// evicted by a strategy are marked in the snapshot, so the strategies running later
+// in the cycle no longer see them.
+type Snapshot struct {
+	lock            sync.RWMutex
+	podsByNode      map[string][]*v1.Pod
+	podsByNamespace map[string][]*v1.Pod
+	evicted         map[types.NamespacedName]struct{}
+}
+
+// NewSnapshot creates a snapshot of the pods. The pods must not be modified afterwards.
+func NewSnapshot(pods []*v1.Pod) *Snapshot {
+	s := &Snapshot{
+		podsByNode:      make(map[string][]*v1.Pod),
+		podsByNamespace: make(map[string][]*v1.Pod),
+		evicted:         make(map[types.NamespacedName]struct{}),
+	}
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) > 0 {
+			s.podsByNode[pod.Spec.NodeName] = append(s.podsByNode[pod.Spec.NodeName], pod)
+		}
+		s.podsByNamespace[pod.Namespace] = append(s.podsByNamespace[pod.Namespace], pod)
+	}
+	return s
+}
+
+// PodsOnNode returns the pods of the node which were not evicted
+func (s *Snapshot) PodsOnNode(nodeName string) []*v1.Pod {
+	return s.withoutEvicted(s.podsByNode[nodeName])
+}
+
+// PodsInNamespace returns the pods of the namespace which were not evicted
+func (s *Snapshot) PodsInNamespace(namespace string) []*v1.Pod {
+	return s.withoutEvicted(s.podsByNamespace[namespace])
+}
+
+// MarkEvicted removes the pod from the pods returned by the snapshot
+func (s *Snapshot) MarkEvicted(pod *v1.Pod) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.evicted[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = struct{}{}
+}
+
+// IsEvicted returns whether the pod was evicted in the cycle of the snapshot
+func (s *Snapshot) IsEvicted(pod *v1.Pod) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.evicted[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+	return ok
+}
+
+func (s *Snapshot) withoutEvicted(pods []*v1.Pod) []*v1.Pod {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if _, ok := s.evicted[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; !ok {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+type snapshotKey struct{}
+
+// WithSnapshot returns a copy of ctx carrying the snapshot. ListPodsOnANode and
+// ListPodsInNamespace read the pods from the snapshot instead of listing them from
+// the API server, and PodEvictor marks the pods it evicts in it.
+func WithSnapshot(ctx context.Context, snapshot *Snapshot) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, snapshot)
+}
+
+// SnapshotFrom returns the snapshot of the context, nil if it has none
+func SnapshotFrom(ctx context.Context) *Snapshot {
+	snapshot, _ := ctx.Value(snapshotKey{}).(*Snapshot)
+	return snapshot
+}
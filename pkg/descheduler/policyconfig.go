@@ -17,14 +17,21 @@ limitations under the License.
 package descheduler
 
 import (
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
 )
 
@@ -39,6 +46,17 @@ func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
 		return nil, fmt.Errorf("failed to read policy config file %q: %+v", policyConfigFile, err)
 	}
 
+	internalPolicy, err := decodePolicy(policyConfigFile, policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePolicy(internalPolicy); err != nil {
+		return nil, fmt.Errorf("invalid policy config %q: %w", policyConfigFile, err)
+	}
+	return internalPolicy, nil
+}
+
+func decodePolicy(policyConfigFile string, policy []byte) (*api.DeschedulerPolicy, error) {
 	versionedPolicy := &v1alpha1.DeschedulerPolicy{}
 
 	decoder := scheme.Codecs.UniversalDecoder(v1alpha1.SchemeGroupVersion)
@@ -53,3 +71,148 @@ func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
 
 	return internalPolicy, nil
 }
+
+// ValidatePolicy checks every enabled strategy of the policy is registered and has
+// valid parameters and schedule. All problems are returned, each error of a strategy
+// carrying its field path in the policy, e.g. strategies[PodLifeTime].params.
+func ValidatePolicy(policy *api.DeschedulerPolicy) utilerrors.Aggregate {
+	var errs []error
+	for _, limit := range []struct {
+		name  string
+		value *int
+	}{
+		{"maxNoOfPodsToEvictPerNode", policy.MaxNoOfPodsToEvictPerNode},
+		{"maxNoOfPodsToEvictPerNamespace", policy.MaxNoOfPodsToEvictPerNamespace},
+		{"maxNoOfPodsToEvictPerOwner", policy.MaxNoOfPodsToEvictPerOwner},
+		{"maxNoOfPodsToEvictPerZone", policy.MaxNoOfPodsToEvictPerZone},
+		{"maxNoOfPodsToEvictTotal", policy.MaxNoOfPodsToEvictTotal},
+	} {
+		if limit.value != nil && *limit.value < 0 {
+			errs = append(errs, field.Invalid(field.NewPath(limit.name), *limit.value, "must not be negative"))
+		}
+	}
+	if policy.EvictionsPerSecond != nil && *policy.EvictionsPerSecond <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("evictionsPerSecond"), *policy.EvictionsPerSecond, "must be greater than 0"))
+	}
+	if policy.EvictionBurst != nil && *policy.EvictionBurst < 1 {
+		errs = append(errs, field.Invalid(field.NewPath("evictionBurst"), *policy.EvictionBurst, "must be at least 1"))
+	}
+	if policy.EvictionCooldown != nil && policy.EvictionCooldown.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("evictionCooldown"), policy.EvictionCooldown.Duration.String(), "must be greater than 0"))
+	}
+	if policy.ReplacementTimeout != nil && policy.ReplacementTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("replacementTimeout"), policy.ReplacementTimeout.Duration.String(), "must be greater than 0"))
+	}
+	errs = append(errs, validateDeleteOptions(field.NewPath("deleteOptions"), policy.DeleteOptions)...)
+	errs = append(errs, validateApprovalWebhook(field.NewPath("approvalWebhook"), policy.ApprovalWebhook)...)
+	for _, name := range enabledStrategiesByWeight(policy.Strategies) {
+		strategy := policy.Strategies[name]
+		strategyPath := field.NewPath("strategies").Key(string(name))
+		s, ok := registry.get(name)
+		if !ok {
+			var names []string
+			for _, registered := range registry.list() {
+				names = append(names, string(registered.Name()))
+			}
+			errs = append(errs, field.NotSupported(strategyPath, string(name), names))
+			continue
+		}
+		if err := s.Validate(strategy.Params); err != nil {
+			errs = append(errs, strategyErrors(strategyPath, err)...)
+		}
+		if _, err := strategySchedule(strategy); err != nil {
+			errs = append(errs, strategyErrors(strategyPath, err)...)
+		}
+		if strategy.Parallelism < 0 {
+			errs = append(errs, field.Invalid(strategyPath.Child("parallelism"), strategy.Parallelism, "must not be negative"))
+		}
+		errs = append(errs, validateDeleteOptions(strategyPath.Child("deleteOptions"), strategy.DeleteOptions)...)
+		if err := validateDisruptionMode(strategyPath.Child("disruptionMode"), strategy.DisruptionMode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateDeleteOptions(path *field.Path, deleteOptions *api.DeleteOptions) []error {
+	if deleteOptions == nil {
+		return nil
+	}
+	var errs []error
+	if deleteOptions.GracePeriodSeconds != nil && *deleteOptions.GracePeriodSeconds < 0 {
+		errs = append(errs, field.Invalid(path.Child("gracePeriodSeconds"), *deleteOptions.GracePeriodSeconds, "must not be negative"))
+	}
+	if deleteOptions.PropagationPolicy != nil {
+		switch *deleteOptions.PropagationPolicy {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+		default:
+			errs = append(errs, field.NotSupported(path.Child("propagationPolicy"), *deleteOptions.PropagationPolicy, []string{
+				string(metav1.DeletePropagationOrphan), string(metav1.DeletePropagationBackground), string(metav1.DeletePropagationForeground),
+			}))
+		}
+	}
+	return errs
+}
+
+func validateApprovalWebhook(path *field.Path, webhook *api.ApprovalWebhook) []error {
+	if webhook == nil {
+		return nil
+	}
+	var errs []error
+	if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), webhook.URL, "must be an absolute http or https URL"))
+	}
+	if len(webhook.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(webhook.CABundle) {
+		errs = append(errs, field.Invalid(path.Child("caBundle"), "", "must contain PEM encoded certificates"))
+	}
+	if webhook.Timeout != nil && webhook.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), webhook.Timeout.Duration.String(), "must be greater than 0"))
+	}
+	switch evictions.FailurePolicy(webhook.FailurePolicy) {
+	case "", evictions.FailurePolicyFail, evictions.FailurePolicyIgnore:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("failurePolicy"), webhook.FailurePolicy, []string{
+			string(evictions.FailurePolicyFail), string(evictions.FailurePolicyIgnore),
+		}))
+	}
+	return errs
+}
+
+func validateDisruptionMode(path *field.Path, mode string) error {
+	if mode == "" {
+		return nil
+	}
+	var modes []string
+	for _, supported := range evictions.DisruptionModes {
+		if mode == string(supported) {
+			return nil
+		}
+		modes = append(modes, string(supported))
+	}
+	return field.NotSupported(path, mode, modes)
+}
+
+// strategyErrors puts the errors of a strategy below its path in the policy. Validate
+// functions of out-of-tree strategies may return plain errors, those are reported at
+// the parameters of the strategy.
+func strategyErrors(strategyPath *field.Path, err error) []error {
+	var list []error
+	if agg, ok := err.(utilerrors.Aggregate); ok {
+		list = agg.Errors()
+	} else {
+		list = []error{err}
+	}
+
+	errs := make([]error, 0, len(list))
+	for _, err := range list {
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			prefixed := *fieldErr
+			prefixed.Field = strategyPath.Child(fieldErr.Field).String()
+			errs = append(errs, &prefixed)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %v", strategyPath.Child("params"), err))
+	}
+	return errs
+}
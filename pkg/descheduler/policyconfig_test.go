@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	negative := -1
+	var zeroRate float32
+	negativeGracePeriod := int64(-1)
+	unknownPropagation := metav1.DeletionPropagation("Unknown")
+	tests := []struct {
+		description string
+		policy      api.DeschedulerPolicy
+		strategies  api.StrategyList
+		fields      []string
+	}{
+		{
+			description: "valid policy",
+			strategies: api.StrategyList{
+				"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true},
+			},
+		},
+		{
+			description: "disabled strategies are not validated",
+			strategies: api.StrategyList{
+				"PodLifeTime": api.DeschedulerStrategy{Enabled: false},
+			},
+		},
+		{
+			description: "unknown strategy",
+			strategies: api.StrategyList{
+				"Unknown": api.DeschedulerStrategy{Enabled: true},
+			},
+			fields: []string{"strategies[Unknown]"},
+		},
+		{
+			description: "negative eviction limits",
+			policy: api.DeschedulerPolicy{
+				MaxNoOfPodsToEvictPerNamespace: &negative,
+				MaxNoOfPodsToEvictTotal:        &negative,
+			},
+			fields: []string{"maxNoOfPodsToEvictPerNamespace", "maxNoOfPodsToEvictTotal"},
+		},
+		{
+			description: "invalid eviction rate, cooldown and replacement timeout",
+			policy: api.DeschedulerPolicy{
+				EvictionsPerSecond: &zeroRate,
+				EvictionBurst:      &negative,
+				EvictionCooldown:   &metav1.Duration{},
+				ReplacementTimeout: &metav1.Duration{},
+			},
+			fields: []string{"evictionBurst", "evictionCooldown", "evictionsPerSecond", "replacementTimeout"},
+		},
+		{
+			description: "invalid delete options",
+			policy: api.DeschedulerPolicy{
+				DeleteOptions: &api.DeleteOptions{PropagationPolicy: &unknownPropagation},
+			},
+			strategies: api.StrategyList{
+				"RemoveDuplicates": api.DeschedulerStrategy{
+					Enabled:       true,
+					DeleteOptions: &api.DeleteOptions{GracePeriodSeconds: &negativeGracePeriod},
+				},
+			},
+			fields: []string{"deleteOptions.propagationPolicy", "strategies[RemoveDuplicates].deleteOptions.gracePeriodSeconds"},
+		},
+		{
+			description: "invalid approval webhook",
+			policy: api.DeschedulerPolicy{
+				ApprovalWebhook: &api.ApprovalWebhook{
+					URL:           "change-freeze.example.com/approve",
+					CABundle:      []byte("not a certificate"),
+					Timeout:       &metav1.Duration{},
+					FailurePolicy: "Retry",
+				},
+			},
+			fields: []string{"approvalWebhook.caBundle", "approvalWebhook.failurePolicy", "approvalWebhook.timeout", "approvalWebhook.url"},
+		},
+		{
+			description: "unknown disruption mode",
+			strategies: api.StrategyList{
+				"RemoveDuplicates":              api.DeschedulerStrategy{Enabled: true, DisruptionMode: "RollingUpdate"},
+				"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true, DisruptionMode: "RolloutRestart"},
+			},
+			fields: []string{"strategies[RemoveDuplicates].disruptionMode"},
+		},
+		{
+			description: "all problems of all strategies are reported",
+			strategies: api.StrategyList{
+				"PodLifeTime": api.DeschedulerStrategy{Enabled: true, Schedule: "every night"},
+				"RemoveDuplicates": api.DeschedulerStrategy{
+					Enabled: true,
+					Params: &api.StrategyParameters{
+						Namespaces: &api.Namespaces{Include: []string{"a"}, Exclude: []string{"b"}},
+					},
+				},
+				"RemovePodsHavingTooManyRestarts": api.DeschedulerStrategy{
+					Enabled:     true,
+					Interval:    &metav1.Duration{},
+					Parallelism: -1,
+					Params:      &api.StrategyParameters{PodsHavingTooManyRestarts: &api.PodsHavingTooManyRestarts{}},
+				},
+			},
+			fields: []string{
+				"strategies[PodLifeTime].params.podLifeTime.maxPodLifeTimeSeconds",
+				"strategies[PodLifeTime].schedule",
+				"strategies[RemoveDuplicates].params.namespaces",
+				"strategies[RemovePodsHavingTooManyRestarts].interval",
+				"strategies[RemovePodsHavingTooManyRestarts].parallelism",
+				"strategies[RemovePodsHavingTooManyRestarts].params.podsHavingTooManyRestarts.podRestartThreshold",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		policy := tc.policy
+		policy.Strategies = tc.strategies
+		err := ValidatePolicy(&policy)
+		var fields []string
+		if err != nil {
+			for _, err := range err.Errors() {
+				fieldErr, ok := err.(*field.Error)
+				if !ok {
+					t.Errorf("%v: expected a field error, got %v", tc.description, err)
+					continue
+				}
+				fields = append(fields, fieldErr.Field)
+			}
+		}
+		sort.Strings(fields)
+		if !reflect.DeepEqual(fields, tc.fields) {
+			t.Errorf("%v: expected errors at %v, got %v", tc.description, tc.fields, fields)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+)
+
+const (
+	// policyValidCondition is the condition of a DeschedulerPolicy reporting the result of its validation
+	policyValidCondition = "Valid"
+)
+
+// policyResourceWatcher keeps the last valid version of a DeschedulerPolicy custom
+// resource and reports the result of the validation of every version in its status.
+type policyResourceWatcher struct {
+	client dynamic.Interface
+	name   string
+
+	lock   sync.RWMutex
+	policy *api.DeschedulerPolicy
+
+	// content is the last seen version of the policy without its metadata and status,
+	// so updates of the status do not trigger a new validation
+	content   map[string]interface{}
+	condition metav1.Condition
+}
+
+func newPolicyResourceWatcher(client dynamic.Interface, name string) *policyResourceWatcher {
+	return &policyResourceWatcher{client: client, name: name}
+}
+
+// Policy returns the last valid policy, nil until a valid policy was seen
+func (w *policyResourceWatcher) Policy() *api.DeschedulerPolicy {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.policy
+}
+
+// Start starts watching the DeschedulerPolicy until the context is cancelled
+// and waits for the informer cache to sync.
+func (w *policyResourceWatcher) Start(ctx context.Context) error {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(w.client, 0, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.name).String()
+	})
+	informer := informerFactory.ForResource(v1alpha1.DeschedulerPolicyResource).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.sync(ctx, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.sync(ctx, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			klog.V(1).InfoS("DeschedulerPolicy was deleted, keeping the last valid policy", "policy", w.name)
+		},
+	})
+
+	informerFactory.Start(ctx.Done())
+	for resource, synced := range informerFactory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("unable to sync cache of %v", resource)
+		}
+	}
+	return nil
+}
+
+// sync validates a new version of the policy and applies it when it is valid
+func (w *policyResourceWatcher) sync(ctx context.Context, obj interface{}) {
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok || object.GetName() != w.name {
+		return
+	}
+
+	content := object.DeepCopy().Object
+	delete(content, "metadata")
+	delete(content, "status")
+	if w.content == nil || !equality.Semantic.DeepEqual(content, w.content) {
+		w.content = content
+		policy, err := convertPolicyResource(object)
+		if err == nil {
+			err = ValidatePolicy(policy)
+		}
+		if err != nil {
+			klog.ErrorS(err, "Invalid DeschedulerPolicy, keeping the previous policy", "policy", w.name)
+			w.condition = metav1.Condition{
+				Type:    policyValidCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ValidationFailed",
+				Message: err.Error(),
+			}
+		} else {
+			w.lock.Lock()
+			w.policy = policy
+			w.lock.Unlock()
+			klog.V(1).InfoS("Loaded DeschedulerPolicy, it applies from the next descheduling cycle", "policy", w.name)
+			w.condition = metav1.Condition{
+				Type:    policyValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "Validated",
+				Message: "The policy applies from the next descheduling cycle",
+			}
+		}
+	}
+
+	if err := w.updateStatus(ctx, object); err != nil {
+		klog.ErrorS(err, "Unable to update DeschedulerPolicy status", "policy", w.name)
+	}
+}
+
+// updateStatus reports the last validation in the status of the object unless it is reported already
+func (w *policyResourceWatcher) updateStatus(ctx context.Context, object *unstructured.Unstructured) error {
+	current := v1alpha1.DeschedulerPolicyStatus{}
+	if status, ok := object.Object["status"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &current); err != nil {
+			return fmt.Errorf("unable to convert status: %v", err)
+		}
+	}
+
+	status := current.DeepCopy()
+	status.ObservedGeneration = object.GetGeneration()
+	condition := w.condition
+	condition.ObservedGeneration = object.GetGeneration()
+	meta.SetStatusCondition(&status.Conditions, condition)
+	if equality.Semantic.DeepEqual(current, *status) {
+		return nil
+	}
+
+	unstructuredStatus, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+	if err != nil {
+		return fmt.Errorf("unable to convert status: %v", err)
+	}
+	updated := object.DeepCopy()
+	updated.Object["status"] = unstructuredStatus
+	_, err = w.client.Resource(v1alpha1.DeschedulerPolicyResource).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// convertPolicyResource converts the custom resource into the internal policy the same way a policy file is
+func convertPolicyResource(object *unstructured.Unstructured) (*api.DeschedulerPolicy, error) {
+	versionedPolicy := &v1alpha1.DeschedulerPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, versionedPolicy); err != nil {
+		return nil, fmt.Errorf("failed decoding DeschedulerPolicy %q: %v", object.GetName(), err)
+	}
+	scheme.Scheme.Default(versionedPolicy)
+
+	internalPolicy := &api.DeschedulerPolicy{}
+	if err := scheme.Scheme.Convert(versionedPolicy, internalPolicy, nil); err != nil {
+		return nil, fmt.Errorf("failed converting versioned policy to internal policy version: %v", err)
+	}
+	return internalPolicy, nil
+}
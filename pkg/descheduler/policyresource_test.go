@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+)
+
+func buildPolicyResource(name string, podLifeTimeParams map[string]interface{}) *unstructured.Unstructured {
+	strategy := map[string]interface{}{"enabled": true}
+	if podLifeTimeParams != nil {
+		strategy["params"] = map[string]interface{}{"podLifeTime": podLifeTimeParams}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v1alpha1.DeschedulerPolicyResource.GroupVersion().String(),
+		"kind":       "DeschedulerPolicy",
+		"metadata":   map[string]interface{}{"name": name},
+		"strategies": map[string]interface{}{"PodLifeTime": strategy},
+	}}
+}
+
+func TestPolicyResourceWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{v1alpha1.DeschedulerPolicyResource: "DeschedulerPolicyList"},
+		buildPolicyResource("default", map[string]interface{}{"maxPodLifeTimeSeconds": int64(86400)}),
+		buildPolicyResource("other", map[string]interface{}{"maxPodLifeTimeSeconds": int64(60)}),
+	)
+	resource := client.Resource(v1alpha1.DeschedulerPolicyResource)
+
+	w := newPolicyResourceWatcher(client, "default")
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Unable to start watching the policy: %v", err)
+	}
+
+	maxPodLifeTime := func() uint {
+		policy := w.Policy()
+		if policy == nil {
+			return 0
+		}
+		return *policy.Strategies["PodLifeTime"].Params.PodLifeTime.MaxPodLifeTimeSeconds
+	}
+	waitFor := func(description string, condition func() bool) {
+		if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return condition(), nil
+		}); err != nil {
+			t.Fatalf("Timed out waiting for %v", description)
+		}
+	}
+	validCondition := func() *metav1.Condition {
+		object, err := resource.Get(ctx, "default", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unable to get the policy: %v", err)
+		}
+		status := v1alpha1.DeschedulerPolicyStatus{}
+		if s, ok := object.Object["status"].(map[string]interface{}); ok {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(s, &status); err != nil {
+				t.Fatalf("Unable to convert status: %v", err)
+			}
+		}
+		return meta.FindStatusCondition(status.Conditions, policyValidCondition)
+	}
+
+	waitFor("the policy to be loaded", func() bool { return maxPodLifeTime() == 86400 })
+	waitFor("the policy to be reported valid", func() bool {
+		condition := validCondition()
+		return condition != nil && condition.Status == metav1.ConditionTrue
+	})
+
+	// PodLifeTime requires maxPodLifeTimeSeconds
+	if _, err := resource.Update(ctx, buildPolicyResource("default", map[string]interface{}{}), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update the policy: %v", err)
+	}
+	waitFor("the policy to be reported invalid", func() bool {
+		condition := validCondition()
+		return condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == "ValidationFailed"
+	})
+	if maxPodLifeTime() != 86400 {
+		t.Errorf("Expected the previous policy to be kept, got maxPodLifeTimeSeconds %v", maxPodLifeTime())
+	}
+
+	if _, err := resource.Update(ctx, buildPolicyResource("default", map[string]interface{}{"maxPodLifeTimeSeconds": int64(3600)}), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update the policy: %v", err)
+	}
+	waitFor("the updated policy to be loaded", func() bool { return maxPodLifeTime() == 3600 })
+	waitFor("the updated policy to be reported valid", func() bool {
+		condition := validCondition()
+		return condition != nil && condition.Status == metav1.ConditionTrue
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// policyReloadPeriod is how often the policy file is checked for changes.
+// Files mounted from a ConfigMap are updated by the kubelet with a delay anyway.
+var policyReloadPeriod = 10 * time.Second
+
+// policyFileWatcher keeps the last valid version of the policy file. The file is
+// polled rather than watched for events, since a ConfigMap volume replaces the
+// whole directory through a symlink instead of writing to the file.
+type policyFileWatcher struct {
+	file string
+
+	lock    sync.RWMutex
+	policy  *api.DeschedulerPolicy
+	content []byte
+}
+
+func newPolicyFileWatcher(file string, policy *api.DeschedulerPolicy) *policyFileWatcher {
+	w := &policyFileWatcher{file: file, policy: policy}
+	// the policy was loaded from the file just before, an error here is retried by reload
+	w.content, _ = ioutil.ReadFile(file)
+	return w
+}
+
+// Policy returns the last valid policy
+func (w *policyFileWatcher) Policy() *api.DeschedulerPolicy {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.policy
+}
+
+// Run checks the policy file for changes until the context is cancelled
+func (w *policyFileWatcher) Run(ctx context.Context) {
+	klog.V(1).InfoS("Watching policy file for changes", "file", w.file, "period", policyReloadPeriod)
+	wait.UntilWithContext(ctx, func(ctx context.Context) { w.reload() }, policyReloadPeriod)
+}
+
+// reload replaces the policy when the content of the file changed and the new
+// policy is valid. An invalid policy is logged once and the previous one is kept.
+func (w *policyFileWatcher) reload() {
+	content, err := ioutil.ReadFile(w.file)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read policy file, keeping the previous policy", "file", w.file)
+		return
+	}
+	if bytes.Equal(content, w.content) {
+		return
+	}
+	w.content = content
+
+	policy, err := decodePolicy(w.file, content)
+	if err == nil {
+		err = ValidatePolicy(policy)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Invalid policy, keeping the previous policy", "file", w.file)
+		return
+	}
+
+	w.lock.Lock()
+	w.policy = policy
+	w.lock.Unlock()
+	klog.V(1).InfoS("Reloaded policy, it applies from the next descheduling cycle", "file", w.file)
+}
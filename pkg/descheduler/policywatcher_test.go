@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestPolicyFileWatcherReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "descheduler-policy")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "policy.yaml")
+
+	writePolicy := func(policy string) {
+		if err := ioutil.WriteFile(file, []byte(policy), 0644); err != nil {
+			t.Fatalf("Unable to write policy file: %v", err)
+		}
+	}
+	maxPodLifeTime := func(w *policyFileWatcher) uint {
+		return *w.Policy().Strategies["PodLifeTime"].Params.PodLifeTime.MaxPodLifeTimeSeconds
+	}
+
+	writePolicy(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+     enabled: true
+     params:
+       podLifeTime:
+         maxPodLifeTimeSeconds: 86400
+`)
+	policy, err := LoadPolicyConfig(file)
+	if err != nil {
+		t.Fatalf("Unable to load policy: %v", err)
+	}
+	w := newPolicyFileWatcher(file, policy)
+
+	w.reload()
+	if w.Policy() != policy {
+		t.Errorf("Expected the policy to be kept when the file did not change")
+	}
+
+	writePolicy(`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+     enabled: true
+     params:
+       podLifeTime:
+         maxPodLifeTimeSeconds: 3600
+`)
+	w.reload()
+	if maxPodLifeTime(w) != 3600 {
+		t.Errorf("Expected the changed policy to be loaded, got maxPodLifeTimeSeconds %v", maxPodLifeTime(w))
+	}
+
+	for _, invalid := range []string{
+		// PodLifeTime requires maxPodLifeTimeSeconds
+		`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+     enabled: true
+`,
+		`apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+     enabled: true
+     schedule: "every night"
+     params:
+       podLifeTime:
+         maxPodLifeTimeSeconds: 60
+`,
+		"strategies: [",
+	} {
+		writePolicy(invalid)
+		w.reload()
+		if maxPodLifeTime(w) != 3600 {
+			t.Errorf("Expected the previous policy to be kept, got maxPodLifeTimeSeconds %v", maxPodLifeTime(w))
+		}
+	}
+}
+
+func TestReloadedPolicyAppliesAtNextCycle(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var lock sync.Mutex
+	var runs []api.StrategyName
+	for _, name := range []api.StrategyName{"ReloadFirst", "ReloadSecond"} {
+		name := name
+		err := RegisterStrategy(NewStrategy(name, nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+			lock.Lock()
+			defer lock.Unlock()
+			runs = append(runs, name)
+		}))
+		if err != nil {
+			t.Fatalf("Unable to register strategy: %v", err)
+		}
+	}
+	countRuns := func(name api.StrategyName) int {
+		lock.Lock()
+		defer lock.Unlock()
+		count := 0
+		for _, run := range runs {
+			if run == name {
+				count++
+			}
+		}
+		return count
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	rs.DeschedulingInterval = 50 * time.Millisecond
+
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{"ReloadFirst": api.DeschedulerStrategy{Enabled: true}},
+	}
+	var policyLock sync.Mutex
+	getPolicy := func() *api.DeschedulerPolicy {
+		policyLock.Lock()
+		defer policyLock.Unlock()
+		return policy
+	}
+
+	stopChannel := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := runDeschedulerStrategies(ctx, rs, getPolicy, "v1beta1", evictionObservers{}, stopChannel); err != nil {
+			t.Errorf("Unable to run descheduler strategies: %v", err)
+		}
+	}()
+
+	waitForRuns := func(name api.StrategyName) {
+		if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return countRuns(name) > 0, nil
+		}); err != nil {
+			t.Fatalf("Strategy %v did not run", name)
+		}
+	}
+	waitForRuns("ReloadFirst")
+
+	policyLock.Lock()
+	policy = &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{"ReloadSecond": api.DeschedulerStrategy{Enabled: true}},
+	}
+	policyLock.Unlock()
+
+	waitForRuns("ReloadSecond")
+	close(stopChannel)
+	<-done
+
+	lock.Lock()
+	defer lock.Unlock()
+	reloaded := false
+	for _, run := range runs {
+		if run == "ReloadSecond" {
+			reloaded = true
+		} else if reloaded {
+			t.Errorf("Expected only the new policy to run after the reload, got runs %v", runs)
+			break
+		}
+	}
+}
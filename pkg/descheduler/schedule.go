@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// strategySchedule returns the schedule a strategy runs on. A nil schedule means
+// the strategy runs on the DeschedulingInterval loop. An invalid schedule is reported
+// as a *field.Error with a path relative to the strategy.
+func strategySchedule(strategy api.DeschedulerStrategy) (cron.Schedule, error) {
+	switch {
+	case strategy.Interval != nil && strategy.Schedule != "":
+		return nil, field.Forbidden(field.NewPath("schedule"), "only one of interval and schedule can be set")
+	case strategy.Interval != nil:
+		if strategy.Interval.Duration < time.Second {
+			return nil, field.Invalid(field.NewPath("interval"), strategy.Interval.Duration.String(), "must be at least 1s")
+		}
+		return cron.Every(strategy.Interval.Duration), nil
+	case strategy.Schedule != "":
+		schedule, err := cron.ParseStandard(strategy.Schedule)
+		if err != nil {
+			return nil, field.Invalid(field.NewPath("schedule"), strategy.Schedule, err.Error())
+		}
+		return schedule, nil
+	}
+	return nil, nil
+}
+
+// cronLogger passes the scheduler logs to klog
+type cronLogger struct{}
+
+func (cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	klog.V(4).InfoS(msg, keysAndValues...)
+}
+
+func (cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	klog.ErrorS(err, msg, keysAndValues...)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestStrategySchedule(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		description string
+		strategy    api.DeschedulerStrategy
+		next        time.Time
+		loop        bool
+		err         bool
+	}{
+		{
+			description: "no interval or schedule",
+			strategy:    api.DeschedulerStrategy{},
+			loop:        true,
+		},
+		{
+			description: "interval",
+			strategy:    api.DeschedulerStrategy{Interval: &metav1.Duration{Duration: 10 * time.Minute}},
+			next:        start.Add(10 * time.Minute),
+		},
+		{
+			description: "cron schedule",
+			strategy:    api.DeschedulerStrategy{Schedule: "0 2 * * *"},
+			next:        start.Add(2 * time.Hour),
+		},
+		{
+			description: "both interval and schedule",
+			strategy:    api.DeschedulerStrategy{Interval: &metav1.Duration{Duration: time.Hour}, Schedule: "@hourly"},
+			err:         true,
+		},
+		{
+			description: "interval too short",
+			strategy:    api.DeschedulerStrategy{Interval: &metav1.Duration{Duration: time.Millisecond}},
+			err:         true,
+		},
+		{
+			description: "invalid cron schedule",
+			strategy:    api.DeschedulerStrategy{Schedule: "every night"},
+			err:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		schedule, err := strategySchedule(tc.strategy)
+		if (err != nil) != tc.err {
+			t.Errorf("%v: unexpected error: %v", tc.description, err)
+			continue
+		}
+		if tc.err {
+			continue
+		}
+		if (schedule == nil) != tc.loop {
+			t.Errorf("%v: expected strategy to run on its own schedule: %v", tc.description, !tc.loop)
+			continue
+		}
+		if schedule != nil && !schedule.Next(start).Equal(tc.next) {
+			t.Errorf("%v: expected next run at %v, got %v", tc.description, tc.next, schedule.Next(start))
+		}
+	}
+}
+
+func TestScheduledStrategiesRunIndependently(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var lock sync.Mutex
+	runs := make(map[api.StrategyName]int)
+	for _, name := range []api.StrategyName{"ScheduledLoop", "ScheduledInterval"} {
+		name := name
+		err := RegisterStrategy(NewStrategy(name, nil, func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+			lock.Lock()
+			defer lock.Unlock()
+			runs[name]++
+		}))
+		if err != nil {
+			t.Fatalf("Unable to register strategy: %v", err)
+		}
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"ScheduledLoop":     api.DeschedulerStrategy{Enabled: true},
+			"ScheduledInterval": api.DeschedulerStrategy{Enabled: true, Interval: &metav1.Duration{Duration: time.Second}},
+		},
+	}
+
+	stopChannel := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, stopChannel); err != nil {
+			t.Errorf("Unable to run descheduler strategies: %v", err)
+		}
+	}()
+
+	time.Sleep(2500 * time.Millisecond)
+	close(stopChannel)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Descheduler did not stop")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	// Without a DeschedulingInterval the strategy without a schedule runs once
+	if runs["ScheduledLoop"] != 1 {
+		t.Errorf("Expected strategy without a schedule to run once, got %v", runs["ScheduledLoop"])
+	}
+	if runs["ScheduledInterval"] < 2 {
+		t.Errorf("Expected strategy with 1s interval to run at least twice, got %v", runs["ScheduledInterval"])
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/informers"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// LoadSnapshot reads the Nodes, Pods, PriorityClasses, Namespaces and PodDisruptionBudgets
+// of a cluster snapshot from the YAML and JSON files in dir and its subdirectories.
+// A file may contain several objects, as separate YAML documents or as a List, e.g. the
+// output of kubectl get -o yaml. Objects of other kinds are skipped.
+func LoadSnapshot(dir string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+		for {
+			document, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("unable to read %q: %v", path, err)
+			}
+			if len(bytes.TrimSpace(document)) == 0 {
+				continue
+			}
+			decoded, err := decodeSnapshotObjects(document)
+			if err != nil {
+				return fmt.Errorf("unable to decode %q: %v", path, err)
+			}
+			objects = append(objects, decoded...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func decodeSnapshotObjects(data []byte) ([]runtime.Object, error) {
+	obj, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if runtime.IsNotRegisteredError(err) {
+		// e.g. custom resources, or policy/v1 PodDisruptionBudgets client-go does not know yet
+		klog.V(1).InfoS("Skipping snapshot object of unknown kind", "kind", gvk)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := obj.(type) {
+	case *v1.Node, *v1.Pod, *v1.Namespace, *schedulingv1.PriorityClass, *policyv1beta1.PodDisruptionBudget, *appsv1.ReplicaSet:
+		return []runtime.Object{obj}, nil
+	case *v1.List:
+		var objects []runtime.Object
+		for _, item := range o.Items {
+			decoded, err := decodeSnapshotObjects(item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
+	}
+	klog.V(1).InfoS("Skipping snapshot object of unsupported kind", "kind", gvk)
+	return nil, nil
+}
+
+// Simulate runs all enabled strategies of the policy once in dry run mode against the
+// objects of a cluster snapshot, without an API server, and writes the planned evictions
+// to out in one of the evictions.ReportFormats.
+func Simulate(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, objects []runtime.Object, format string, out io.Writer) error {
+	if err := evictions.ValidateReportFormat(format); err != nil {
+		return err
+	}
+
+	client := fakeclientset.NewSimpleClientset()
+	for _, obj := range objects {
+		if err := client.Tracker().Add(obj); err != nil {
+			return fmt.Errorf("unable to load snapshot object: %v", err)
+		}
+	}
+	rs.Client = client
+	rs.DryRun = true
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
+	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+	pdbLister := sharedInformerFactory.Policy().V1beta1().PodDisruptionBudgets().Lister()
+	sharedInformerFactory.Start(stopChannel)
+	sharedInformerFactory.WaitForCacheSync(stopChannel)
+
+	strategyNames := enabledStrategiesByWeight(deschedulerPolicy.Strategies)
+	podEvictor, ok := runCycle(ctx, rs, nodeInformer, podLister, pdbLister, deschedulerPolicy, strategyNames, policyv1beta1.SchemeGroupVersion.String(), evictionObservers{})
+	if !ok {
+		return fmt.Errorf("unable to run the strategies, the snapshot needs at least two ready nodes")
+	}
+	return evictions.WriteDryRunReport(out, format, evictions.DryRunReport{Evictions: podEvictor.EvictionRecords()})
+}
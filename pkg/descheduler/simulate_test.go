@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+const snapshotNodes = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: n1
+  status:
+    allocatable: {cpu: "2", memory: 4Gi, pods: "10"}
+    conditions: [{type: Ready, status: "True"}]
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: n2
+  status:
+    allocatable: {cpu: "2", memory: 4Gi, pods: "10"}
+    conditions: [{type: Ready, status: "True"}]
+`
+
+const snapshotPods = `apiVersion: v1
+kind: Pod
+metadata:
+  name: old
+  namespace: default
+  creationTimestamp: %q
+  ownerReferences: [{apiVersion: apps/v1, kind: ReplicaSet, name: rs1, uid: "1"}]
+spec:
+  nodeName: n1
+  priority: 5
+  containers: [{name: c, image: i}]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: skipped, namespace: default}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: new
+  namespace: default
+  creationTimestamp: %q
+  ownerReferences: [{apiVersion: apps/v1, kind: ReplicaSet, name: rs1, uid: "1"}]
+spec:
+  nodeName: n2
+  containers: [{name: c, image: i}]
+`
+
+func TestDecodeSnapshotObjects(t *testing.T) {
+	snapshot := `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata: {name: n1}
+- apiVersion: apps/v1
+  kind: ReplicaSet
+  metadata: {name: web-1234, namespace: default}
+- apiVersion: example.com/v1
+  kind: Widget
+  metadata: {name: w1, namespace: default}
+- apiVersion: policy/v1
+  kind: PodDisruptionBudget
+  metadata: {name: pdb-v1, namespace: default}
+  spec: {minAvailable: 1, selector: {matchLabels: {app: web}}}
+- apiVersion: policy/v1beta1
+  kind: PodDisruptionBudget
+  metadata: {name: pdb-v1beta1, namespace: default}
+  spec: {minAvailable: 1, selector: {matchLabels: {app: web}}}
+`
+	objects, err := decodeSnapshotObjects([]byte(snapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode snapshot: %v", err)
+	}
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.(metav1.Object).GetName())
+	}
+	if expected := []string{"n1", "web-1234", "pdb-v1beta1"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected objects %v, got %v", expected, names)
+	}
+
+	if _, err := decodeSnapshotObjects([]byte("kind: [")); err == nil {
+		t.Errorf("Expected an error decoding an invalid object")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "descheduler-snapshot")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	files := map[string]string{
+		"nodes.yaml":      snapshotNodes,
+		"pods/pods.yml":   fmt.Sprintf(snapshotPods, now.Add(-48*time.Hour).UTC().Format(time.RFC3339), now.Add(-time.Hour).UTC().Format(time.RFC3339)),
+		"pods/README.txt": "not a snapshot file",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Unable to create directory: %v", err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Unable to write snapshot file: %v", err)
+		}
+	}
+
+	objects, err := LoadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("Unable to load snapshot: %v", err)
+	}
+	if len(objects) != 4 {
+		t.Fatalf("Expected 2 nodes and 2 pods to be loaded, got %v objects", len(objects))
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	maxPodLifeTime := uint(86400)
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"PodLifeTime": api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTime},
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := Simulate(context.Background(), rs, policy, objects, evictions.ReportFormatJSON, &out); err != nil {
+		t.Fatalf("Unable to simulate policy: %v", err)
+	}
+	report := evictions.DryRunReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Unable to decode report %q: %v", out.String(), err)
+	}
+	if len(report.Evictions) != 1 || report.Evictions[0].Pod != "old" || report.Evictions[0].Owner != "ReplicaSet/rs1" || report.Evictions[0].Node != "n1" {
+		t.Errorf("Expected only pod old to be evicted, got %+v", report.Evictions)
+	}
+}
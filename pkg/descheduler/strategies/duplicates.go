@@ -18,7 +18,6 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"math"
 	"reflect"
 	"sort"
@@ -36,19 +35,12 @@ import (
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-func validateRemoveDuplicatePodsParams(params *api.StrategyParameters) error {
+// ValidateRemoveDuplicatePodsParams checks the parameters of the RemoveDuplicates strategy.
+func ValidateRemoveDuplicatePodsParams(params *api.StrategyParameters) error {
 	if params == nil {
 		return nil
 	}
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
-	}
-
-	return nil
+	return validateCommonParams(params).ToAggregate()
 }
 
 type podOwner struct {
@@ -67,7 +59,7 @@ func RemoveDuplicatePods(
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
 ) {
-	if err := validateRemoveDuplicatePodsParams(strategy.Params); err != nil {
+	if err := ValidateRemoveDuplicatePodsParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid RemoveDuplicatePods parameters")
 		return
 	}
@@ -91,6 +83,9 @@ func RemoveDuplicatePods(
 	nodeMap := make(map[string]*v1.Node)
 
 	for _, node := range nodes {
+		if ctx.Err() != nil {
+			return
+		}
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(ctx,
 			client,
@@ -184,6 +179,9 @@ func RemoveDuplicatePods(
 
 	// 1. how many pods can be evicted to respect uniform placement of pods among viable nodes?
 	for ownerKey, nodes := range duplicatePods {
+		if ctx.Err() != nil {
+			return
+		}
 		upperAvg := int(math.Ceil(float64(ownerKeyOccurence[ownerKey]) / float64(nodeCount)))
 		for nodeName, pods := range nodes {
 			klog.V(2).InfoS("Average occurrence per node", "node", klog.KObj(nodeMap[nodeName]), "ownerKey", ownerKey, "avg", upperAvg)
@@ -194,6 +192,12 @@ func RemoveDuplicatePods(
 				for _, pod := range pods[upperAvg-1:] {
 					if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[nodeName], "RemoveDuplicatePods"); err != nil {
 						klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+						if evictions.IsStopError(err) {
+							return
+						}
+						if evictions.IsPodLimitError(err) {
+							continue
+						}
 						break
 					}
 				}
@@ -23,6 +23,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -53,22 +54,36 @@ const (
 	MaxResourcePercentage = 100
 )
 
-func validateLowNodeUtilizationParams(params *api.StrategyParameters) error {
+// ValidateLowNodeUtilizationParams checks the parameters of the LowNodeUtilization strategy.
+func ValidateLowNodeUtilizationParams(params *api.StrategyParameters) error {
+	thresholdsPath := paramsPath.Child("nodeResourceUtilizationThresholds")
 	if params == nil || params.NodeResourceUtilizationThresholds == nil {
-		return fmt.Errorf("NodeResourceUtilizationThresholds not set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
+		return field.Required(thresholdsPath, "")
 	}
 
-	return nil
+	allErrs := validateCommonParams(params)
+	thresholds := params.NodeResourceUtilizationThresholds.Thresholds
+	targetThresholds := params.NodeResourceUtilizationThresholds.TargetThresholds
+	if err := validateThresholds(thresholds); err != nil {
+		allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("thresholds"), thresholds, err.Error()))
+	}
+	if err := validateThresholds(targetThresholds); err != nil {
+		allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("targetThresholds"), targetThresholds, err.Error()))
+	}
+	// compare thresholds only once both are valid
+	if len(allErrs) == 0 {
+		if err := validateStrategyConfig(thresholds, targetThresholds); err != nil {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("targetThresholds"), targetThresholds, err.Error()))
+		}
+	}
+	return allErrs.ToAggregate()
 }
 
 // LowNodeUtilization evicts pods from overutilized nodes to underutilized nodes. Note that CPU/Memory requests are used
 // to calculate nodes' utilization and not the actual resource usage.
 func LowNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
 	// TODO: May be create a struct for the strategy as well, so that we don't have to pass along the all the params?
-	if err := validateLowNodeUtilizationParams(strategy.Params); err != nil {
+	if err := ValidateLowNodeUtilizationParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid LowNodeUtilization parameters")
 		return
 	}
@@ -80,10 +95,6 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 
 	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds
 	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds
-	if err := validateStrategyConfig(thresholds, targetThresholds); err != nil {
-		klog.ErrorS(err, "LowNodeUtilization config is not valid")
-		return
-	}
 	// check if Pods/CPU/Mem are set, if not, set them to 100
 	if _, ok := thresholds[v1.ResourcePods]; !ok {
 		thresholds[v1.ResourcePods] = MaxResourcePercentage
@@ -305,6 +316,9 @@ func evictPodsFromTargetNodes(
 	)
 
 	for _, node := range targetNodes {
+		if ctx.Err() != nil || podEvictor.TotalLimitReached() {
+			return
+		}
 		klog.V(3).InfoS("Evicting pods from node", "node", klog.KObj(node.node), "usage", node.usage)
 
 		nonRemovablePods, removablePods := classifyPods(node.allPods, podFilter)
@@ -359,6 +373,9 @@ func evictPods(
 			success, err := podEvictor.EvictPod(ctx, pod, nodeUsage.node, "LowNodeUtilization")
 			if err != nil {
 				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				if evictions.IsPodLimitError(err) {
+					continue
+				}
 				break
 			}
 
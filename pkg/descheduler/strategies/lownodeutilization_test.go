@@ -27,6 +27,7 @@ import (
 	"k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -767,6 +768,9 @@ func TestWithTaints(t *testing.T) {
 				item.nodes,
 				false,
 				false,
+				evictions.WithEvictionFunc(func(ctx context.Context, client clientset.Interface, eviction *v1beta1.Eviction) error {
+					return client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+				}),
 			)
 
 			LowNodeUtilization(ctx, fakeClient, strategy, item.nodes, podEvictor)
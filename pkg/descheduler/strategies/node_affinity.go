@@ -18,9 +18,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -31,24 +31,24 @@ import (
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-func validatePodsViolatingNodeAffinityParams(params *api.StrategyParameters) error {
+// ValidateRemovePodsViolatingNodeAffinityParams checks the parameters of the RemovePodsViolatingNodeAffinity strategy.
+func ValidateRemovePodsViolatingNodeAffinityParams(params *api.StrategyParameters) error {
 	if params == nil || len(params.NodeAffinityType) == 0 {
-		return fmt.Errorf("NodeAffinityType is empty")
-	}
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
+		return field.Required(paramsPath.Child("nodeAffinityType"), "")
 	}
 
-	return nil
+	allErrs := validateCommonParams(params)
+	for i, nodeAffinity := range params.NodeAffinityType {
+		if nodeAffinity != "requiredDuringSchedulingIgnoredDuringExecution" {
+			allErrs = append(allErrs, field.NotSupported(paramsPath.Child("nodeAffinityType").Index(i), nodeAffinity, []string{"requiredDuringSchedulingIgnoredDuringExecution"}))
+		}
+	}
+	return allErrs.ToAggregate()
 }
 
 // RemovePodsViolatingNodeAffinity evicts pods on nodes which violate node affinity
 func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validatePodsViolatingNodeAffinityParams(strategy.Params); err != nil {
+	if err := ValidateRemovePodsViolatingNodeAffinityParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeAffinity parameters")
 		return
 	}
@@ -71,7 +71,7 @@ func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Inter
 
 		switch nodeAffinity {
 		case "requiredDuringSchedulingIgnoredDuringExecution":
-			for _, node := range nodes {
+			processNodes(ctx, strategy, nodes, podEvictor, func(node *v1.Node) {
 				klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 
 				pods, err := podutil.ListPodsOnANode(
@@ -95,11 +95,14 @@ func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Inter
 						klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
 						if _, err := podEvictor.EvictPod(ctx, pod, node, "NodeAffinity"); err != nil {
 							klog.ErrorS(err, "Error evicting pod")
+							if evictions.IsPodLimitError(err) {
+								continue
+							}
 							break
 						}
 					}
 				}
-			}
+			})
 		default:
 			klog.ErrorS(nil, "Invalid nodeAffinityType", "nodeAffinity", nodeAffinity)
 		}
@@ -18,7 +18,6 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
@@ -30,25 +29,18 @@ import (
 	"k8s.io/klog/v2"
 )
 
-func validateRemovePodsViolatingNodeTaintsParams(params *api.StrategyParameters) error {
+// ValidateRemovePodsViolatingNodeTaintsParams checks the parameters of the RemovePodsViolatingNodeTaints strategy.
+func ValidateRemovePodsViolatingNodeTaintsParams(params *api.StrategyParameters) error {
 	if params == nil {
 		return nil
 	}
 
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
-	}
-
-	return nil
+	return validateCommonParams(params).ToAggregate()
 }
 
 // RemovePodsViolatingNodeTaints evicts pods on the node which violate NoSchedule Taints on nodes
 func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsViolatingNodeTaintsParams(strategy.Params); err != nil {
+	if err := ValidateRemovePodsViolatingNodeTaintsParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeTaints parameters")
 		return
 	}
@@ -67,7 +59,7 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority))
 
-	for _, node := range nodes {
+	processNodes(ctx, strategy, nodes, podEvictor, func(node *v1.Node) {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(
 			ctx,
@@ -91,9 +83,12 @@ func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interfa
 				klog.V(2).InfoS("Not all taints with NoSchedule effect are tolerated after update for pod on node", "pod", klog.KObj(pods[i]), "node", klog.KObj(node))
 				if _, err := podEvictor.EvictPod(ctx, pods[i], node, "NodeTaint"); err != nil {
 					klog.ErrorS(err, "Error evicting pod")
+					if evictions.IsPodLimitError(err) {
+						continue
+					}
 					break
 				}
 			}
 		}
-	}
+	})
 }
@@ -0,0 +1,43 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// processNodes calls process for every node, on up to strategy.Parallelism nodes at
+// the same time. No more nodes are processed once the context is cancelled or
+// the pod evictor reached its total limit.
+func processNodes(ctx context.Context, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, process func(node *v1.Node)) {
+	workers := strategy.Parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	workqueue.ParallelizeUntil(ctx, workers, len(nodes), func(piece int) {
+		if podEvictor.TotalLimitReached() {
+			return
+		}
+		process(nodes[piece])
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestProcessNodes(t *testing.T) {
+	var nodes []*v1.Node
+	for i := 0; i < 20; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("n%d", i), 2000, 3000, 10, nil))
+	}
+
+	podEvictor := evictions.NewPodEvictor(&fake.Clientset{}, "v1", false, 0, nodes, false, false)
+	for _, parallelism := range []int{0, 1, 4} {
+		var lock sync.Mutex
+		processed := make(map[string]int)
+		running, maxRunning := 0, 0
+		processNodes(context.Background(), api.DeschedulerStrategy{Parallelism: parallelism}, nodes, podEvictor, func(node *v1.Node) {
+			lock.Lock()
+			processed[node.Name]++
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+		})
+
+		if len(processed) != len(nodes) {
+			t.Errorf("Parallelism %v: expected %v nodes to be processed, got %v", parallelism, len(nodes), len(processed))
+		}
+		for name, count := range processed {
+			if count != 1 {
+				t.Errorf("Parallelism %v: expected node %v to be processed once, got %v", parallelism, name, count)
+			}
+		}
+		expectedMax := parallelism
+		if expectedMax < 1 {
+			expectedMax = 1
+		}
+		if maxRunning > expectedMax {
+			t.Errorf("Parallelism %v: expected at most %v nodes to be processed at the same time, got %v", parallelism, expectedMax, maxRunning)
+		}
+	}
+}
+
+func TestProcessNodesTotalLimitReached(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+
+	podEvictor := evictions.NewPodEvictor(&fake.Clientset{}, "v1", true, 0, []*v1.Node{n1, n2}, false, false, evictions.WithEvictionLimits(evictions.EvictionLimits{Total: 1}))
+	if _, err := podEvictor.EvictPod(ctx, p1, n1); err != nil {
+		t.Fatalf("Unable to evict pod: %v", err)
+	}
+
+	processed := 0
+	processNodes(ctx, api.DeschedulerStrategy{}, []*v1.Node{n1, n2}, podEvictor, func(node *v1.Node) {
+		processed++
+	})
+	if processed != 0 {
+		t.Errorf("Expected no node to be processed once the total limit is reached, got %v", processed)
+	}
+}
@@ -18,7 +18,6 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
@@ -31,25 +30,18 @@ import (
 	"k8s.io/klog/v2"
 )
 
-func validateRemovePodsViolatingInterPodAntiAffinityParams(params *api.StrategyParameters) error {
+// ValidateRemovePodsViolatingInterPodAntiAffinityParams checks the parameters of the RemovePodsViolatingInterPodAntiAffinity strategy.
+func ValidateRemovePodsViolatingInterPodAntiAffinityParams(params *api.StrategyParameters) error {
 	if params == nil {
 		return nil
 	}
 
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
-	}
-
-	return nil
+	return validateCommonParams(params).ToAggregate()
 }
 
 // RemovePodsViolatingInterPodAntiAffinity evicts pods on the node which are having a pod affinity rules.
 func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsViolatingInterPodAntiAffinityParams(strategy.Params); err != nil {
+	if err := ValidateRemovePodsViolatingInterPodAntiAffinityParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid RemovePodsViolatingInterPodAntiAffinity parameters")
 		return
 	}
@@ -68,7 +60,7 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority))
 
-	for _, node := range nodes {
+	processNodes(ctx, strategy, nodes, podEvictor, func(node *v1.Node) {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(
 			ctx,
@@ -88,6 +80,9 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 				success, err := podEvictor.EvictPod(ctx, pods[i], node, "InterPodAntiAffinity")
 				if err != nil {
 					klog.ErrorS(err, "Error evicting pod")
+					if evictions.IsPodLimitError(err) {
+						continue
+					}
 					break
 				}
 
@@ -101,7 +96,7 @@ func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clients
 				}
 			}
 		}
-	}
+	})
 }
 
 // checkPodsWithAntiAffinityExist checks if there are other pods on the node that the current pod cannot tolerate.
@@ -18,10 +18,10 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 
 	v1 "k8s.io/api/core/v1"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -31,33 +31,24 @@ import (
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-func validatePodLifeTimeParams(params *api.StrategyParameters) error {
+// ValidatePodLifeTimeParams checks the parameters of the PodLifeTime strategy.
+func ValidatePodLifeTimeParams(params *api.StrategyParameters) error {
 	if params == nil || params.PodLifeTime == nil || params.PodLifeTime.MaxPodLifeTimeSeconds == nil {
-		return fmt.Errorf("MaxPodLifeTimeSeconds not set")
+		return field.Required(paramsPath.Child("podLifeTime", "maxPodLifeTimeSeconds"), "")
 	}
 
-	if params.PodLifeTime.PodStatusPhases != nil {
-		for _, phase := range params.PodLifeTime.PodStatusPhases {
-			if phase != string(v1.PodPending) && phase != string(v1.PodRunning) {
-				return fmt.Errorf("only Pending and Running phases are supported in PodLifeTime")
-			}
+	allErrs := validateCommonParams(params)
+	for i, phase := range params.PodLifeTime.PodStatusPhases {
+		if phase != string(v1.PodPending) && phase != string(v1.PodRunning) {
+			allErrs = append(allErrs, field.NotSupported(paramsPath.Child("podLifeTime", "podStatusPhases").Index(i), phase, []string{string(v1.PodPending), string(v1.PodRunning)}))
 		}
 	}
-
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
-	}
-
-	return nil
+	return allErrs.ToAggregate()
 }
 
 // PodLifeTime evicts pods on nodes that were created more than strategy.Params.MaxPodLifeTimeSeconds seconds ago.
 func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validatePodLifeTimeParams(strategy.Params); err != nil {
+	if err := ValidatePodLifeTimeParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid PodLifeTime parameters")
 		return
 	}
@@ -88,7 +79,7 @@ func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.D
 		}
 	}
 
-	for _, node := range nodes {
+	processNodes(ctx, strategy, nodes, podEvictor, func(node *v1.Node) {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 
 		pods := listOldPodsOnNode(ctx, client, node, includedNamespaces, excludedNamespaces, *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds, filter)
@@ -100,11 +91,14 @@ func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.D
 
 			if err != nil {
 				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				if evictions.IsPodLimitError(err) {
+					continue
+				}
 				break
 			}
 		}
 
-	}
+	})
 }
 
 func listOldPodsOnNode(ctx context.Context, client clientset.Interface, node *v1.Node, includedNamespaces, excludedNamespaces []string, maxPodLifeTimeSeconds uint, filter func(pod *v1.Pod) bool) []*v1.Pod {
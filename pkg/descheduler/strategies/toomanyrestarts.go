@@ -18,9 +18,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -30,27 +30,24 @@ import (
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-func validateRemovePodsHavingTooManyRestartsParams(params *api.StrategyParameters) error {
-	if params == nil || params.PodsHavingTooManyRestarts == nil || params.PodsHavingTooManyRestarts.PodRestartThreshold < 1 {
-		return fmt.Errorf("PodsHavingTooManyRestarts threshold not set")
+// ValidateRemovePodsHavingTooManyRestartsParams checks the parameters of the RemovePodsHavingTooManyRestarts strategy.
+func ValidateRemovePodsHavingTooManyRestartsParams(params *api.StrategyParameters) error {
+	if params == nil || params.PodsHavingTooManyRestarts == nil {
+		return field.Required(paramsPath.Child("podsHavingTooManyRestarts", "podRestartThreshold"), "")
 	}
 
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	allErrs := validateCommonParams(params)
+	if params.PodsHavingTooManyRestarts.PodRestartThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(paramsPath.Child("podsHavingTooManyRestarts", "podRestartThreshold"), params.PodsHavingTooManyRestarts.PodRestartThreshold, "must be at least 1"))
 	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
-	}
-
-	return nil
+	return allErrs.ToAggregate()
 }
 
 // RemovePodsHavingTooManyRestarts removes the pods that have too many restarts on node.
 // There are too many cases leading this issue: Volume mount failed, app error due to nodes' different settings.
 // As of now, this strategy won't evict daemonsets, mirror pods, critical pods and pods with local storages.
 func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsHavingTooManyRestartsParams(strategy.Params); err != nil {
+	if err := ValidateRemovePodsHavingTooManyRestartsParams(strategy.Params); err != nil {
 		klog.ErrorS(err, "Invalid RemovePodsHavingTooManyRestarts parameters")
 		return
 	}
@@ -69,7 +66,7 @@ func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Inter
 
 	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority))
 
-	for _, node := range nodes {
+	processNodes(ctx, strategy, nodes, podEvictor, func(node *v1.Node) {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListPodsOnANode(
 			ctx,
@@ -81,7 +78,7 @@ func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Inter
 		)
 		if err != nil {
 			klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
-			continue
+			return
 		}
 
 		for i, pod := range pods {
@@ -95,10 +92,13 @@ func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Inter
 			}
 			if _, err := podEvictor.EvictPod(ctx, pods[i], node, "TooManyRestarts"); err != nil {
 				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+				if evictions.IsPodLimitError(err) {
+					continue
+				}
 				break
 			}
 		}
-	}
+	})
 }
 
 // calcContainerRestarts get container restarts and init container restarts.
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
@@ -45,17 +46,21 @@ type topology struct {
 	pods []*v1.Pod
 }
 
+// ValidateRemovePodsViolatingTopologySpreadConstraintParams checks the parameters of the RemovePodsViolatingTopologySpreadConstraint strategy.
+func ValidateRemovePodsViolatingTopologySpreadConstraintParams(params *api.StrategyParameters) error {
+	if params == nil {
+		return nil
+	}
+	return validateCommonParams(params).ToAggregate()
+}
+
 func validateAndParseTopologySpreadParams(ctx context.Context, client clientset.Interface, params *api.StrategyParameters) (int32, sets.String, sets.String, error) {
 	var includedNamespaces, excludedNamespaces sets.String
 	if params == nil {
 		return 0, includedNamespaces, excludedNamespaces, nil
 	}
-	// At most one of include/exclude can be set
-	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
-		return 0, includedNamespaces, excludedNamespaces, fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
-		return 0, includedNamespaces, excludedNamespaces, fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
+	if err := ValidateRemovePodsViolatingTopologySpreadConstraintParams(params); err != nil {
+		return 0, includedNamespaces, excludedNamespaces, err
 	}
 	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, params)
 	if err != nil {
@@ -78,7 +83,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 ) {
 	thresholdPriority, includedNamespaces, excludedNamespaces, err := validateAndParseTopologySpreadParams(ctx, client, strategy.Params)
 	if err != nil {
-		klog.ErrorS(err, "Invalid PodLifeTime parameters")
+		klog.ErrorS(err, "Invalid RemovePodsViolatingTopologySpreadConstraint parameters")
 		return
 	}
 
@@ -114,7 +119,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 			(len(excludedNamespaces) > 0 && excludedNamespaces.Has(namespace.Name)) {
 			continue
 		}
-		namespacePods, err := client.CoreV1().Pods(namespace.Name).List(ctx, metav1.ListOptions{})
+		namespacePods, err := podutil.ListPodsInNamespace(ctx, client, namespace.Name)
 		if err != nil {
 			klog.ErrorS(err, "Couldn't list pods in namespace", "namespace", namespace)
 			continue
@@ -123,7 +128,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 		// ...where there is a topology constraint
 		//namespaceTopologySpreadConstrainPods := make([]v1.Pod, 0, len(namespacePods.Items))
 		namespaceTopologySpreadConstraints := make(map[v1.TopologySpreadConstraint]struct{})
-		for _, pod := range namespacePods.Items {
+		for _, pod := range namespacePods {
 			for _, constraint := range pod.Spec.TopologySpreadConstraints {
 				// Ignore soft topology constraints if they are not included
 				if (strategy.Params == nil || !strategy.Params.IncludeSoftConstraints) && constraint.WhenUnsatisfiable != v1.DoNotSchedule {
@@ -156,14 +161,14 @@ func RemovePodsViolatingTopologySpreadConstraint(
 			// 3. for each evictable pod in that namespace
 			// (this loop is where we count the number of pods per topologyValue that match this constraint's selector)
 			var sumPods float64
-			for i := range namespacePods.Items {
+			for _, pod := range namespacePods {
 				// 4. if the pod matches this TopologySpreadConstraint LabelSelector
-				if !selector.Matches(labels.Set(namespacePods.Items[i].Labels)) {
+				if !selector.Matches(labels.Set(pod.Labels)) {
 					continue
 				}
 
 				// 5. If the pod's node matches this constraint'selector topologyKey, create a topoPair and add the pod
-				node, ok := nodeMap[namespacePods.Items[i].Spec.NodeName]
+				node, ok := nodeMap[pod.Spec.NodeName]
 				if !ok {
 					// If ok is false, node is nil in which case node.Labels will panic. In which case a pod is yet to be scheduled. So it's safe to just continue here.
 					continue
@@ -175,7 +180,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 				// 6. create a topoPair with key as this TopologySpreadConstraint
 				topoPair := topologyPair{key: constraint.TopologyKey, value: nodeValue}
 				// 7. add the pod with key as this topoPair
-				constraintTopologies[topoPair] = append(constraintTopologies[topoPair], &namespacePods.Items[i])
+				constraintTopologies[topoPair] = append(constraintTopologies[topoPair], pod)
 				sumPods++
 			}
 			if topologyIsBalanced(constraintTopologies, constraint) {
@@ -192,7 +197,9 @@ func RemovePodsViolatingTopologySpreadConstraint(
 		}
 		if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[pod.Spec.NodeName], "PodTopologySpread"); err != nil {
 			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
-			break
+			if evictions.IsStopError(err) {
+				break
+			}
 		}
 	}
 }
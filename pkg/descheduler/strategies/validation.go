@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// paramsPath is the path the validation errors of the strategy parameters are
+// reported at, relative to the strategy in the policy
+var paramsPath = field.NewPath("params")
+
+// validateCommonParams checks the parameters shared by the strategies
+func validateCommonParams(params *api.StrategyParameters) field.ErrorList {
+	var allErrs field.ErrorList
+	// At most one of include/exclude can be set
+	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Forbidden(paramsPath.Child("namespaces"), "only one of Include/Exclude namespaces can be set"))
+	}
+	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
+		allErrs = append(allErrs, field.Forbidden(paramsPath.Child("thresholdPriorityClassName"), "only one of thresholdPriority and thresholdPriorityClassName can be set"))
+	}
+	return allErrs
+}
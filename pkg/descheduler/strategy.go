@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+)
+
+// Strategy is a descheduling strategy which can be enabled in the DeschedulerPolicy
+// under its name.
+type Strategy interface {
+	// Name is the key the strategy is configured under in the policy.
+	Name() api.StrategyName
+	// Validate checks the strategy parameters. The strategy is not run when it returns an error.
+	Validate(params *api.StrategyParameters) error
+	// Run evicts pods through the given PodEvictor. In dry run mode the PodEvictor
+	// only records the proposed evictions.
+	Run(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor)
+}
+
+// StrategyFunction runs a strategy over the given nodes.
+type StrategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor)
+
+// ValidateFunction checks the parameters of a strategy.
+type ValidateFunction func(params *api.StrategyParameters) error
+
+type funcStrategy struct {
+	name     api.StrategyName
+	validate ValidateFunction
+	run      StrategyFunction
+}
+
+// NewStrategy builds a Strategy out of a validation and a run function.
+// A nil validate function accepts any parameters.
+func NewStrategy(name api.StrategyName, validate ValidateFunction, run StrategyFunction) Strategy {
+	return &funcStrategy{name: name, validate: validate, run: run}
+}
+
+func (s *funcStrategy) Name() api.StrategyName {
+	return s.name
+}
+
+func (s *funcStrategy) Validate(params *api.StrategyParameters) error {
+	if s.validate == nil {
+		return nil
+	}
+	return s.validate(params)
+}
+
+func (s *funcStrategy) Run(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+	s.run(ctx, client, strategy, nodes, podEvictor)
+}
+
+// strategyRegistry keeps the strategies known to the descheduler by name
+type strategyRegistry struct {
+	lock       sync.RWMutex
+	strategies map[api.StrategyName]Strategy
+}
+
+func (r *strategyRegistry) register(strategy Strategy) error {
+	if strategy == nil || strategy.Name() == "" {
+		return fmt.Errorf("strategy must have a non-empty name")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.strategies[strategy.Name()]; ok {
+		return fmt.Errorf("strategy %q is already registered", strategy.Name())
+	}
+	r.strategies[strategy.Name()] = strategy
+	return nil
+}
+
+func (r *strategyRegistry) get(name api.StrategyName) (Strategy, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	strategy, ok := r.strategies[name]
+	return strategy, ok
+}
+
+func (r *strategyRegistry) list() []Strategy {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := make([]Strategy, 0, len(r.strategies))
+	for _, strategy := range r.strategies {
+		list = append(list, strategy)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
+var registry = &strategyRegistry{strategies: make(map[api.StrategyName]Strategy)}
+
+func init() {
+	for _, strategy := range []Strategy{
+		NewStrategy("RemoveDuplicates", strategies.ValidateRemoveDuplicatePodsParams, strategies.RemoveDuplicatePods),
+		NewStrategy("LowNodeUtilization", strategies.ValidateLowNodeUtilizationParams, strategies.LowNodeUtilization),
+		NewStrategy("RemovePodsViolatingInterPodAntiAffinity", strategies.ValidateRemovePodsViolatingInterPodAntiAffinityParams, strategies.RemovePodsViolatingInterPodAntiAffinity),
+		NewStrategy("RemovePodsViolatingNodeAffinity", strategies.ValidateRemovePodsViolatingNodeAffinityParams, strategies.RemovePodsViolatingNodeAffinity),
+		NewStrategy("RemovePodsViolatingNodeTaints", strategies.ValidateRemovePodsViolatingNodeTaintsParams, strategies.RemovePodsViolatingNodeTaints),
+		NewStrategy("RemovePodsHavingTooManyRestarts", strategies.ValidateRemovePodsHavingTooManyRestartsParams, strategies.RemovePodsHavingTooManyRestarts),
+		NewStrategy("PodLifeTime", strategies.ValidatePodLifeTimeParams, strategies.PodLifeTime),
+		NewStrategy("RemovePodsViolatingTopologySpreadConstraint", strategies.ValidateRemovePodsViolatingTopologySpreadConstraintParams, strategies.RemovePodsViolatingTopologySpreadConstraint),
+	} {
+		if err := registry.register(strategy); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterStrategy makes a strategy available to the descheduler under its name.
+// Strategies have to be registered before Run is called. Registering a strategy
+// under a name that is already taken, including the name of a built-in strategy,
+// returns an error.
+func RegisterStrategy(strategy Strategy) error {
+	return registry.register(strategy)
+}
+
+// RegisteredStrategies returns all registered strategies sorted by name.
+func RegisteredStrategies() []Strategy {
+	return registry.list()
+}
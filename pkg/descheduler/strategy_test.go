@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRegisterStrategy(t *testing.T) {
+	r := &strategyRegistry{strategies: make(map[api.StrategyName]Strategy)}
+	noop := func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {}
+
+	if err := r.register(NewStrategy("Custom", nil, noop)); err != nil {
+		t.Fatalf("Unexpected error registering a strategy: %v", err)
+	}
+	if err := r.register(NewStrategy("Custom", nil, noop)); err == nil {
+		t.Errorf("Expected an error registering a strategy under a duplicate name")
+	}
+	if err := r.register(NewStrategy("", nil, noop)); err == nil {
+		t.Errorf("Expected an error registering a strategy without a name")
+	}
+	if _, ok := r.get("Custom"); !ok {
+		t.Errorf("Expected strategy %q to be registered", "Custom")
+	}
+}
+
+func TestBuiltinStrategiesRegistered(t *testing.T) {
+	expected := []api.StrategyName{
+		"LowNodeUtilization",
+		"PodLifeTime",
+		"RemoveDuplicates",
+		"RemovePodsHavingTooManyRestarts",
+		"RemovePodsViolatingInterPodAntiAffinity",
+		"RemovePodsViolatingNodeAffinity",
+		"RemovePodsViolatingNodeTaints",
+		"RemovePodsViolatingTopologySpreadConstraint",
+	}
+	for _, name := range expected {
+		if _, ok := registry.get(name); !ok {
+			t.Errorf("Expected built-in strategy %q to be registered", name)
+		}
+	}
+	if err := RegisterStrategy(NewStrategy("PodLifeTime", nil, nil)); err == nil {
+		t.Errorf("Expected an error overriding a built-in strategy")
+	}
+}
+
+func TestRunRegisteredStrategy(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var validated, ran int
+	name := api.StrategyName("TestRunRegisteredStrategy")
+	err := RegisterStrategy(NewStrategy(
+		name,
+		func(params *api.StrategyParameters) error {
+			validated++
+			if params == nil {
+				return fmt.Errorf("params not set")
+			}
+			return nil
+		},
+		func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+			ran++
+			if len(nodes) != 2 {
+				t.Errorf("Expected 2 nodes, got %v", len(nodes))
+			}
+		},
+	))
+	if err != nil {
+		t.Fatalf("Unable to register strategy: %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+
+	for _, tc := range []struct {
+		description string
+		strategy    api.DeschedulerStrategy
+		ran         int
+	}{
+		{
+			description: "disabled strategy is not run",
+			strategy:    api.DeschedulerStrategy{Enabled: false, Params: &api.StrategyParameters{}},
+			ran:         0,
+		},
+		{
+			description: "strategy with invalid params is not run",
+			strategy:    api.DeschedulerStrategy{Enabled: true},
+			ran:         0,
+		},
+		{
+			description: "enabled strategy is run",
+			strategy:    api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{}},
+			ran:         1,
+		},
+	} {
+		ran = 0
+		dp := &api.DeschedulerPolicy{
+			Strategies: api.StrategyList{name: tc.strategy},
+		}
+		if err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", nil, make(chan struct{})); err != nil {
+			t.Fatalf("%v: unable to run descheduler strategies: %v", tc.description, err)
+		}
+		if ran != tc.ran {
+			t.Errorf("%v: expected strategy to run %v times, got %v", tc.description, tc.ran, ran)
+		}
+	}
+	if validated != 2 {
+		t.Errorf("Expected strategy params to be validated 2 times, got %v", validated)
+	}
+}
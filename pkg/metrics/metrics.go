@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides the Prometheus metrics exposed by the descheduler
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	// DeschedulerSubsystem is the subsystem name of all descheduler metrics
+	DeschedulerSubsystem = "descheduler"
+)
+
+var (
+	// PodsEvicted counts the pods evicted, labelled by the strategy and the reason it gave
+	PodsEvicted = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_evicted_total",
+			Help:           "Number of pods evicted by the descheduler.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "namespace", "node", "reason"})
+
+	// PodEvictionsFailed counts the evictions rejected by the API server, labelled
+	// by the strategy and the reason of the API error, e.g. TooManyRequests
+	PodEvictionsFailed = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pod_evictions_failed_total",
+			Help:           "Number of pod evictions which failed.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "namespace", "node", "reason"})
+
+	// PodsNotEvictable counts the pods each strategy run skipped as not evictable
+	PodsNotEvictable = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_not_evictable_total",
+			Help:           "Number of pods skipped by a strategy because they are not evictable.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy"})
+
+	// StrategyDuration observes how long a single run of a strategy takes
+	StrategyDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "strategy_duration_seconds",
+			Help:           "Time it takes a strategy to run in seconds.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 18),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy"})
+
+	// CycleDuration observes how long a descheduling cycle running all of its strategies takes
+	CycleDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycle_duration_seconds",
+			Help:           "Time it takes a descheduling cycle to run all its strategies in seconds.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 18),
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	metricsList = []metrics.Registerable{
+		PodsEvicted,
+		PodEvictionsFailed,
+		PodsNotEvictable,
+		StrategyDuration,
+		CycleDuration,
+	}
+)
+
+var registerMetrics sync.Once
+
+// Register registers the descheduler metrics with the legacy registry.
+// Metrics which are not registered are not recorded.
+func Register() {
+	registerMetrics.Do(func() {
+		for _, metric := range metricsList {
+			legacyregistry.MustRegister(metric)
+		}
+	})
+}
+
+// ListenAndServe serves the registered metrics on the /metrics path of the address
+func ListenAndServe(address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", legacyregistry.Handler())
+	return http.ListenAndServe(address, mux)
+}
@@ -583,8 +583,10 @@ func TestDeschedulingInterval(t *testing.T) {
 			t.Errorf("Error when checking support for eviction: %v", err)
 		}
 
+		eventRecorder, stopEvents := descheduler.NewEventRecorder(s.Client)
+		defer stopEvents()
 		stopChannel := make(chan struct{})
-		if err := descheduler.RunDeschedulerStrategies(ctx, s, deschedulerPolicy, evictionPolicyGroupVersion, stopChannel); err != nil {
+		if err := descheduler.RunDeschedulerStrategies(ctx, s, deschedulerPolicy, evictionPolicyGroupVersion, eventRecorder, stopChannel); err != nil {
 			t.Errorf("Error running descheduler strategies: %+v", err)
 		}
 		c <- true